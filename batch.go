@@ -0,0 +1,132 @@
+package sqlq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var ErrBatchRowTooLarge = errors.New("Row doesn't fit in one statement, raise the max statement size or split its values")
+var ErrBatchMaxBytesNegative = errors.New("Max statement size can't be negative value")
+
+// Statement is a query with the args to bind to its placeholders.
+type Statement struct {
+	SQL  string
+	Args []interface{}
+}
+
+// TxBeginner is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Batches splits the rows into as many statements as needed to stay under the dialect's
+// MaxPlaceholders, MaxInsertRows and maxBytes, use 0 for no size limit like MySQL's max_allowed_packet.
+// An insert from FromSelect() is always one statement.
+func (ib *InsertBuilder) Batches(maxBytes int) ([]Statement, error) {
+	// the whole insert is written once first so mistakes found while writing are returned like ToSql() does
+	query, args, err := ib.ToSql()
+	if err != nil {
+		return nil, err
+	} else if maxBytes < 0 {
+		return nil, ErrBatchMaxBytesNegative
+	} else if ib.query != nil {
+		return []Statement{{query, args}}, nil
+	}
+
+	// what follows the rows is measured with the most placeholders so it fits wherever it ends up
+	dialect := newSqlWriter(ib.dialect, false).dialect
+	limit := dialect.MaxPlaceholders()
+	tail := &sqlWriter{dialect: dialect, args: make([]interface{}, limit)}
+	if err := ib.writeTail(tail); err != nil {
+		return nil, err
+	}
+	limit -= len(tail.args) - limit
+	maxRows := dialect.MaxInsertRows()
+	if ib.merging(dialect) {
+		maxRows = 0
+	}
+
+	var statements []Statement
+	var w *sqlWriter
+	rows := 0
+	flush := func() error {
+		if err := ib.writeTail(w); err != nil {
+			return err
+		}
+		statements = append(statements, Statement{w.String(), w.args})
+		w = nil
+		return nil
+	}
+	for i := 0; i < len(ib.rows); {
+		if w != nil && maxRows > 0 && rows >= maxRows {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		if w == nil {
+			w = newSqlWriter(ib.dialect, false)
+			if err := ib.writeHead(w); err != nil {
+				return nil, err
+			}
+			rows = 0
+		}
+		// the row is written on its own first so it can be left out when it doesn't fit,
+		// its args are appended after the statement's ones and only kept when it fits
+		row := &sqlWriter{dialect: w.dialect, args: w.args}
+		if rows > 0 {
+			row.WriteString(", ")
+		}
+		if err := row.writeRows(ib.rows[i:i+1], ""); err != nil {
+			return nil, err
+		}
+		if len(row.args) > limit || (maxBytes > 0 && w.Len()+row.Len()+tail.Len() > maxBytes) {
+			if rows == 0 {
+				return nil, &ValidationError{Statement: "INSERT", Clause: "VALUES", Row: i + 1, Err: ErrBatchRowTooLarge}
+			}
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		w.WriteString(row.String())
+		w.args = row.args
+		rows++
+		i++
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// ExecBatches runs the Batches in one transaction and returns the number of inserted rows,
+// nothing is inserted when one of them fails.
+func (ib *InsertBuilder) ExecBatches(ctx context.Context, db TxBeginner, maxBytes int) (int64, error) {
+	statements, err := ib.Batches(maxBytes)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, statement := range statements {
+		result, err := tx.ExecContext(ctx, statement.SQL, statement.Args...)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		total += affected
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,142 @@
+package sqlq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInsertBuilder_Batches(t *testing.T) {
+	rows := func(n int) *InsertBuilder {
+		ib := Insert().Into("users").Columns("name", "age")
+		for i := 0; i < n; i++ {
+			ib.Values("a", i)
+		}
+		return ib
+	}
+	tables := []struct {
+		Builder  *InsertBuilder
+		MaxBytes int
+		Sizes    []int
+		Error    error
+	}{
+		{rows(3), 0, []int{3}, nil},
+		{rows(1000).Dialect(SQLite), 0, []int{499, 499, 2}, nil},
+		{rows(2000).Dialect(SQLServer), 0, []int{1000, 1000}, nil},
+		{rows(40000).Dialect(PostgreSQL), 0, []int{32767, 7233}, nil},
+		{rows(5), len("INSERT INTO users (name, age) VALUES (?, ?), (?, ?)"), []int{2, 2, 1}, nil},
+		{rows(5), 10, nil, ErrBatchRowTooLarge},
+		{rows(5), -1, nil, ErrBatchMaxBytesNegative},
+		{Insert().Into("users").Columns("name"), 0, nil, ErrInsertEmptyValues},
+	}
+	for _, table := range tables {
+		statements, err := table.Builder.Batches(table.MaxBytes)
+		var sizes []int
+		for _, statement := range statements {
+			sizes = append(sizes, len(statement.Args)/2)
+			if strings.Count(statement.SQL, "(") != len(statement.Args)/2+1 {
+				t.Errorf("Expected a row for every 2 args\nGot %v\n", statement.SQL)
+			}
+			if table.MaxBytes > 0 && len(statement.SQL) > table.MaxBytes {
+				t.Errorf("Expected at most %v bytes\nGot %v\n", table.MaxBytes, len(statement.SQL))
+			}
+		}
+		if !reflect.DeepEqual(sizes, table.Sizes) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v\nGot %v %v\n", table.Sizes, table.Error, sizes, err)
+		}
+	}
+
+	// SQL Server takes at most 1000 rows in VALUES, but a MERGE source has no row limit
+	single := Insert().Into("tags").Columns("name")
+	upsert := Insert().Into("tags").Columns("name").OnConflict("name").DoNothing()
+	for i := 0; i < 2500; i++ {
+		single.Values(i)
+		upsert.Values(i)
+	}
+	for _, table := range []struct {
+		Builder *InsertBuilder
+		Sizes   []int
+	}{
+		{single.Dialect(SQLServer), []int{1000, 1000, 500}},
+		{upsert.Dialect(SQLServer), []int{2100, 400}},
+	} {
+		statements, err := table.Builder.Batches(0)
+		var sizes []int
+		for _, statement := range statements {
+			sizes = append(sizes, len(statement.Args))
+		}
+		if !reflect.DeepEqual(sizes, table.Sizes) || err != nil {
+			t.Errorf("Expected %v\nGot %v %v\n", table.Sizes, sizes, err)
+		}
+	}
+
+	statements, err := Insert().Into("users").Columns("name", "age").Values("a", 1).Values("b", 2).Values("c", 3).
+		Dialect(PostgreSQL).Batches(len("INSERT INTO users (name, age) VALUES ($1, $2), ($3, $4)"))
+	expected := []Statement{
+		{"INSERT INTO users (name, age) VALUES ($1, $2), ($3, $4)", []interface{}{"a", 1, "b", 2}},
+		{"INSERT INTO users (name, age) VALUES ($1, $2)", []interface{}{"c", 3}},
+	}
+	if !reflect.DeepEqual(statements, expected) || err != nil {
+		t.Errorf("Expected %v\nGot %v %v\n", expected, statements, err)
+	}
+}
+
+func TestInsertBuilder_ExecBatches(t *testing.T) {
+	tables := []struct {
+		FailAt   int
+		Affected int64
+		Execs    []string
+		Commit   bool
+	}{
+		{-1, 5, []string{
+			"INSERT INTO users (name) VALUES (?), (?)",
+			"INSERT INTO users (name) VALUES (?), (?)",
+			"INSERT INTO users (name) VALUES (?)",
+		}, true},
+		{1, 0, []string{
+			"INSERT INTO users (name) VALUES (?), (?)",
+		}, false},
+	}
+	for _, table := range tables {
+		conn := &batchConn{failAt: table.FailAt}
+		db := sql.OpenDB(batchConnector{conn})
+		ib := Insert().Into("users").Columns("name").Values("a").Values("b").Values("c").Values("d").Values("e")
+		affected, err := ib.ExecBatches(context.Background(), db, len("INSERT INTO users (name) VALUES (?), (?)"))
+		db.Close()
+		if affected != table.Affected || (err == nil) != table.Commit || !reflect.DeepEqual(conn.execs, table.Execs) ||
+			conn.committed != table.Commit || conn.rolledBack == table.Commit {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v %v\n", table.Affected, table.Execs, table.Commit, affected, conn.execs, conn.committed, err)
+		}
+	}
+}
+
+// batchConn is a database/sql driver that records statements and fails the one at failAt.
+type batchConn struct {
+	failAt     int
+	execs      []string
+	committed  bool
+	rolledBack bool
+}
+
+type batchConnector struct{ conn *batchConn }
+
+func (c batchConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c batchConnector) Driver() driver.Driver                        { return nil }
+
+func (c *batchConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *batchConn) Close() error                              { return nil }
+func (c *batchConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *batchConn) Commit() error                             { c.committed = true; return nil }
+func (c *batchConn) Rollback() error                           { c.rolledBack = true; return nil }
+
+func (c *batchConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if len(c.execs) == c.failAt {
+		return nil, errors.New("exec failed")
+	}
+	c.execs = append(c.execs, query)
+	return driver.RowsAffected(len(args)), nil
+}
@@ -0,0 +1,205 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+var ErrCompoundQueries = errors.New("At least two Select queries are required to do Compound Operation, use Union(), UnionAll(), Intersect() or Except() function to specify Select queries")
+var ErrCompoundWithSame = errors.New("Select queries of a Compound Operation can't have different With queries with the same name")
+
+type compoundPart struct {
+	operator string
+	query    *SelectBuilder
+}
+
+type CompoundBuilder struct {
+	parts   []compoundPart
+	order   []orderBy
+	paging  pagination
+	dialect Dialect
+}
+
+func (cb *CompoundBuilder) add(operator string, queries []*SelectBuilder) *CompoundBuilder {
+	for _, query := range queries {
+		cb.parts = append(cb.parts, compoundPart{operator, query})
+	}
+	return cb
+}
+
+func (cb *CompoundBuilder) Union(queries ...*SelectBuilder) *CompoundBuilder {
+	return cb.add("UNION", queries)
+}
+
+func (cb *CompoundBuilder) UnionAll(queries ...*SelectBuilder) *CompoundBuilder {
+	return cb.add("UNION ALL", queries)
+}
+
+func (cb *CompoundBuilder) Intersect(queries ...*SelectBuilder) *CompoundBuilder {
+	return cb.add("INTERSECT", queries)
+}
+
+func (cb *CompoundBuilder) Except(queries ...*SelectBuilder) *CompoundBuilder {
+	return cb.add("EXCEPT", queries)
+}
+
+func (cb *CompoundBuilder) OrderBy(column interface{}, order string) *CompoundBuilder {
+	cb.order = append(cb.order, orderBy{column, order})
+	return cb
+}
+
+func (cb *CompoundBuilder) Limit(limit int) *CompoundBuilder {
+	cb.paging.setLimit(limit)
+	return cb
+}
+
+func (cb *CompoundBuilder) NoLimit() *CompoundBuilder {
+	cb.paging.setNoLimit()
+	return cb
+}
+
+func (cb *CompoundBuilder) Offset(offset int) *CompoundBuilder {
+	cb.paging.offset = offset
+	return cb
+}
+
+func (cb *CompoundBuilder) Page(page int, size int) *CompoundBuilder {
+	cb.paging.setPage(page, size)
+	return cb
+}
+
+func (cb *CompoundBuilder) Dialect(dialect Dialect) *CompoundBuilder {
+	cb.dialect = dialect
+	return cb
+}
+
+func (cb *CompoundBuilder) Sql() (string, error) {
+	w := newSqlWriter(cb.dialect, true)
+	if err := cb.write(w); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+func (cb *CompoundBuilder) ToSql() (string, []interface{}, error) {
+	w := newSqlWriter(cb.dialect, false)
+	if err := cb.write(w); err != nil {
+		return "", nil, err
+	}
+	return w.String(), w.args, nil
+}
+
+func (cb *CompoundBuilder) write(w *sqlWriter) (err error) {
+	defer w.enter("SELECT", &err)()
+	p := problems{statement: "SELECT"}
+	if len(cb.parts) < 2 {
+		p.add(&ValidationError{Clause: "UNION", Err: ErrCompoundQueries})
+	}
+	for _, part := range cb.parts {
+		if part.query == nil {
+			p.add(&ValidationError{Clause: part.operator, Err: ErrSubqueryNil})
+		}
+	}
+	cb.paging.check(&p)
+	if err := p.err(); err != nil {
+		return err
+	}
+	with, parts, err := cb.hoistWith()
+	if err != nil {
+		return err
+	}
+
+	// TOP and ROW_NUMBER() can't page a compound directly, so it is paged as a derived table
+	if paging := w.dialect.PagingStyle(); cb.paging.paged() && (paging == PagingTop || paging == PagingRowNumber) {
+		outer := Select("*").From(As(&CompoundBuilder{parts: parts}, "sqlq_compound"))
+		outer.with = with
+		outer.order = cb.order
+		outer.paging = cb.paging
+		return outer.write(w)
+	}
+
+	w.clause = "WITH"
+	if err := w.writeWith(with); err != nil {
+		return err
+	}
+	for i, part := range parts {
+		w.clause = part.operator
+		if i > 0 {
+			w.WriteString(" " + part.operator + " ")
+		}
+		// ORDER BY and LIMIT of a part only apply to the part when it is a derived table
+		if part.query.paging.paged() {
+			w.WriteString("SELECT * FROM ")
+			if err := w.writeSubquery(part.query); err != nil {
+				return err
+			}
+			w.WriteString(" AS sqlq_part" + strconv.Itoa(i+1))
+		} else if err := part.query.write(w); err != nil {
+			return err
+		}
+	}
+	w.clause = "ORDER BY"
+	if len(cb.order) > 0 {
+		w.WriteString(" ")
+		if err := w.writeOrderBy(cb.order); err != nil {
+			return err
+		}
+	}
+	cb.paging.write(w)
+	return nil
+}
+
+// hoistWith moves the WITH of the parts in front of the compound, where WITH is allowed, and leaves out
+// the ORDER BY of parts without a limit, which can't change their rows and SQL Server rejects in a derived table.
+func (cb *CompoundBuilder) hoistWith() (withClause, []compoundPart, error) {
+	var with withClause
+	parts := make([]compoundPart, len(cb.parts))
+	for i, part := range cb.parts {
+		query := *part.query
+		for _, c := range query.with.ctes {
+			if found := findCte(with.ctes, c.name); found == nil {
+				with.ctes = append(with.ctes, c)
+			} else if !sameQuery(found.query, c.query) {
+				return with, nil, &ValidationError{Clause: "WITH", Columns: []string{c.name}, Err: ErrCompoundWithSame}
+			}
+		}
+		with.recursive = with.recursive || query.with.recursive
+		query.with = withClause{}
+		if !query.paging.paged() {
+			query.order = nil
+		}
+		parts[i] = compoundPart{part.operator, &query}
+	}
+	return with, parts, nil
+}
+
+func sameQuery(a interface{}, b interface{}) bool {
+	t := reflect.TypeOf(a)
+	return t != nil && t.Comparable() && a == b
+}
+
+func findCte(ctes []cte, name string) *cte {
+	for i := range ctes {
+		if ctes[i].name == name {
+			return &ctes[i]
+		}
+	}
+	return nil
+}
+
+func Union(queries ...*SelectBuilder) *CompoundBuilder {
+	return (&CompoundBuilder{}).Union(queries...)
+}
+
+func UnionAll(queries ...*SelectBuilder) *CompoundBuilder {
+	return (&CompoundBuilder{}).UnionAll(queries...)
+}
+
+func Intersect(queries ...*SelectBuilder) *CompoundBuilder {
+	return (&CompoundBuilder{}).Intersect(queries...)
+}
+
+func Except(queries ...*SelectBuilder) *CompoundBuilder {
+	return (&CompoundBuilder{}).Except(queries...)
+}
@@ -0,0 +1,150 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUnion(t *testing.T) {
+	a, b := Select("id"), Select("id")
+	tables := []struct {
+		Result *CompoundBuilder
+		Output *CompoundBuilder
+	}{
+		{
+			Union(a, b),
+			&CompoundBuilder{parts: []compoundPart{{"UNION", a}, {"UNION", b}}},
+		},
+		{
+			UnionAll(a).Intersect(b).Except(a),
+			&CompoundBuilder{parts: []compoundPart{{"UNION ALL", a}, {"INTERSECT", b}, {"EXCEPT", a}}},
+		},
+	}
+	for _, table := range tables {
+		if !reflect.DeepEqual(table.Result, table.Output) {
+			t.Errorf("Expected %v got %v", table.Output, table.Result)
+		}
+	}
+}
+
+func TestCompoundBuilder_ToSql(t *testing.T) {
+	users := Select("id", "name").From("users").Where("active", "=", true)
+	admins := Select("id", "name").From("admins").Where("level", ">", 2)
+	latest := Select("id", "name").From("guests").OrderBy("created_at", "DESC").Limit(5)
+	tables := []struct {
+		Builder *CompoundBuilder
+		Dialect Dialect
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Union(users, admins),
+			PostgreSQL,
+			"SELECT id, name FROM users WHERE active = $1 UNION SELECT id, name FROM admins WHERE level > $2",
+			[]interface{}{true, 2},
+			nil,
+		},
+		{
+			UnionAll(users, admins).OrderBy("name", "ASC").Limit(10).Offset(20),
+			MySQL,
+			"SELECT id, name FROM users WHERE active = ? UNION ALL SELECT id, name FROM admins WHERE level > ? " +
+				"ORDER BY name ASC LIMIT 10 OFFSET 20",
+			[]interface{}{true, 2},
+			nil,
+		},
+		{
+			Union(users, latest).Except(admins),
+			SQLite,
+			"SELECT id, name FROM users WHERE active = ? UNION SELECT * FROM (SELECT id, name FROM guests ORDER BY created_at DESC LIMIT 5) " +
+				"AS sqlq_part2 EXCEPT SELECT id, name FROM admins WHERE level > ?",
+			[]interface{}{true, 2},
+			nil,
+		},
+		{
+			Intersect(users, admins).OrderBy("name", "ASC").Limit(10),
+			SQLServer,
+			"SELECT TOP 10 * FROM (SELECT id, name FROM users WHERE active = @p1 INTERSECT SELECT id, name FROM admins WHERE level > @p2) " +
+				"AS sqlq_compound ORDER BY name ASC",
+			[]interface{}{true, 2},
+			nil,
+		},
+		{
+			Union(users, admins).OrderBy("name", "ASC"),
+			SQLServer,
+			"SELECT id, name FROM users WHERE active = @p1 UNION SELECT id, name FROM admins WHERE level > @p2 ORDER BY name ASC",
+			[]interface{}{true, 2},
+			nil,
+		},
+		{
+			Union(users, Select("id", "name").From("guests").OrderBy("created_at", "DESC")),
+			SQLServer,
+			"SELECT id, name FROM users WHERE active = @p1 UNION SELECT id, name FROM guests",
+			[]interface{}{true},
+			nil,
+		},
+		{
+			Union(users, Select("id", "name").From("vip").With("vip", admins)).Union(Select("id", "name").From("vip").With("vip", admins)),
+			PostgreSQL,
+			"WITH vip AS (SELECT id, name FROM admins WHERE level > $1) SELECT id, name FROM users WHERE active = $2 " +
+				"UNION SELECT id, name FROM vip UNION SELECT id, name FROM vip",
+			[]interface{}{2, true},
+			nil,
+		},
+		{
+			Union(users, Select("id", "name").From("vip").With("vip", admins)).OrderBy("name", "ASC").Limit(10),
+			SQLServer,
+			"WITH vip AS (SELECT id, name FROM admins WHERE level > @p1) SELECT TOP 10 * FROM (SELECT id, name FROM users WHERE active = @p2 " +
+				"UNION SELECT id, name FROM vip) AS sqlq_compound ORDER BY name ASC",
+			[]interface{}{2, true},
+			nil,
+		},
+		{
+			Union(Select("id", "name").From("vip").With("vip", users), Select("id", "name").From("vip").With("vip", admins)),
+			MySQL,
+			"",
+			nil,
+			ErrCompoundWithSame,
+		},
+		{
+			Union(users),
+			MySQL,
+			"",
+			nil,
+			ErrCompoundQueries,
+		},
+		{
+			Union(users, admins).Limit(-1),
+			MySQL,
+			"",
+			nil,
+			ErrSelectLimitNegative,
+		},
+		{
+			Union(users, Select("id")),
+			MySQL,
+			"",
+			nil,
+			ErrSelectEmptyTable,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.Dialect(table.Dialect).ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v %v\nGot %v %v %v\n", table.Dialect.Name(), table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestCompoundBuilder_Subquery(t *testing.T) {
+	ids := Union(Select("user_id").From("orders"), Select("user_id").From("reviews"))
+	result, err := Select("name").From("users").Where("id", "IN", ids).Sql()
+	if result != "SELECT name FROM users WHERE id IN (SELECT user_id FROM orders UNION SELECT user_id FROM reviews)" || err != nil {
+		t.Errorf("Got %v %v", result, err)
+	}
+	result, err = Select("t.user_id").From(As(ids, "t")).Sql()
+	if result != "SELECT t.user_id FROM (SELECT user_id FROM orders UNION SELECT user_id FROM reviews) AS t" || err != nil {
+		t.Errorf("Got %v %v", result, err)
+	}
+}
@@ -0,0 +1,357 @@
+package sqlq
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var ErrConditionOperatorInvalid = errors.New("Operator must be one of =, !=, <>, <, <=, >, >=, LIKE, NOT LIKE, ILIKE, NOT ILIKE, IN, NOT IN, BETWEEN, NOT BETWEEN, IS or IS NOT")
+var ErrConditionInValues = errors.New("In requires a list of values or a subquery")
+var ErrConditionBetweenValues = errors.New("Between requires two values, the low and the high one")
+var ErrConditionValueList = errors.New("Operator compares a single value, use In to compare with a list")
+
+var operators = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "ILIKE": true, "NOT ILIKE": true,
+	"IN": true, "NOT IN": true, "BETWEEN": true, "NOT BETWEEN": true, "IS": true, "IS NOT": true,
+}
+
+type Condition interface {
+	writeCondition(w *sqlWriter) error
+}
+
+type condition struct {
+	column   interface{}
+	operator string
+	value    interface{}
+}
+
+type conjunction struct {
+	operator   string
+	conditions []Condition
+}
+
+type negation struct {
+	condition Condition
+}
+
+type exists struct {
+	not   bool
+	query *SelectBuilder
+}
+
+func Cond(column interface{}, operator string, value interface{}) Condition {
+	return condition{column, operator, value}
+}
+
+func Eq(column interface{}, value interface{}) Condition {
+	return condition{column, "=", value}
+}
+
+func NotEq(column interface{}, value interface{}) Condition {
+	return condition{column, "<>", value}
+}
+
+func Lt(column interface{}, value interface{}) Condition {
+	return condition{column, "<", value}
+}
+
+func Lte(column interface{}, value interface{}) Condition {
+	return condition{column, "<=", value}
+}
+
+func Gt(column interface{}, value interface{}) Condition {
+	return condition{column, ">", value}
+}
+
+func Gte(column interface{}, value interface{}) Condition {
+	return condition{column, ">=", value}
+}
+
+func Like(column interface{}, pattern interface{}) Condition {
+	return condition{column, "LIKE", pattern}
+}
+
+func NotLike(column interface{}, pattern interface{}) Condition {
+	return condition{column, "NOT LIKE", pattern}
+}
+
+// ILike matches the pattern ignoring case, dialects without ILIKE compare both sides in lower case.
+func ILike(column interface{}, pattern interface{}) Condition {
+	return condition{column, "ILIKE", pattern}
+}
+
+// In takes a slice of any type or a subquery, an empty slice matches no row.
+func In(column interface{}, values interface{}) Condition {
+	return condition{column, "IN", values}
+}
+
+// NotIn takes a slice of any type or a subquery, an empty slice matches every row.
+func NotIn(column interface{}, values interface{}) Condition {
+	return condition{column, "NOT IN", values}
+}
+
+func Between(column interface{}, low interface{}, high interface{}) Condition {
+	return condition{column, "BETWEEN", []interface{}{low, high}}
+}
+
+func IsNull(column interface{}) Condition {
+	return condition{column, "IS", nil}
+}
+
+func IsNotNull(column interface{}) Condition {
+	return condition{column, "IS NOT", nil}
+}
+
+func And(conditions ...Condition) Condition {
+	return conjunction{"AND", conditions}
+}
+
+func Or(conditions ...Condition) Condition {
+	return conjunction{"OR", conditions}
+}
+
+func Not(condition Condition) Condition {
+	return negation{condition}
+}
+
+func Exists(query *SelectBuilder) Condition {
+	return exists{false, query}
+}
+
+func NotExists(query *SelectBuilder) Condition {
+	return exists{true, query}
+}
+
+func (c condition) writeCondition(w *sqlWriter) error {
+	operator, ok := normalizeOperator(c.operator)
+	if !ok {
+		w.record(&ValidationError{Columns: columnNames(c.column), Err: ErrConditionOperatorInvalid})
+		return nil
+	}
+	switch operator {
+	case "IN", "NOT IN":
+		return c.writeIn(w, operator)
+	case "BETWEEN", "NOT BETWEEN":
+		values, ok := listValues(c.value)
+		if !ok || len(values) != 2 {
+			return ErrConditionBetweenValues
+		}
+		if err := w.writeColumn(c.column); err != nil {
+			return err
+		}
+		w.WriteString(" " + operator + " ")
+		if err := w.writeValue(values[0]); err != nil {
+			return err
+		}
+		w.WriteString(" AND ")
+		return w.writeValue(values[1])
+	}
+	if _, ok := listValues(c.value); ok {
+		return ErrConditionValueList
+	}
+	null, err := isNull(c.value)
+	if err != nil {
+		return err
+	}
+	if (operator == "ILIKE" || operator == "NOT ILIKE") && !w.dialect.Supports(FeatureILike) {
+		w.WriteString("LOWER(")
+		if err := w.writeColumn(c.column); err != nil {
+			return err
+		}
+		w.WriteString(") " + strings.TrimSuffix(operator, "ILIKE") + "LIKE LOWER(")
+		if err := w.writeValue(c.value); err != nil {
+			return err
+		}
+		w.WriteString(")")
+		return nil
+	}
+	if err := w.writeColumn(c.column); err != nil {
+		return err
+	}
+	if null {
+		switch operator {
+		case "=", "IS":
+			w.WriteString(" IS NULL")
+			return nil
+		case "!=", "<>", "IS NOT":
+			w.WriteString(" IS NOT NULL")
+			return nil
+		}
+	}
+	w.WriteString(" " + operator + " ")
+	return w.writeValue(c.value)
+}
+
+func (c condition) writeIn(w *sqlWriter, operator string) error {
+	switch values := c.value.(type) {
+	case *SelectBuilder, *CompoundBuilder, Expr:
+		if err := w.writeColumn(c.column); err != nil {
+			return err
+		}
+		w.WriteString(" " + operator + " ")
+		return w.writeValue(values)
+	}
+	values, ok := listValues(c.value)
+	if !ok {
+		return ErrConditionInValues
+	}
+	if len(values) <= 0 {
+		// IN () isn't valid SQL, nothing is in an empty list so the condition is always false or true for NOT IN
+		if operator == "IN" {
+			w.WriteString("1 = 0")
+		} else {
+			w.WriteString("1 = 1")
+		}
+		return nil
+	}
+	if err := w.writeColumn(c.column); err != nil {
+		return err
+	}
+	w.WriteString(" " + operator + " ")
+	return w.writeRows([][]interface{}{values}, "")
+}
+
+// listValues returns the elements of a slice or array, []byte and types that implement driver.Valuer
+// are a single value.
+func listValues(value interface{}) ([]interface{}, bool) {
+	if values, ok := value.([]interface{}); ok {
+		return values, true
+	}
+	if _, ok := value.(driver.Valuer); ok {
+		return nil, false
+	}
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	values := make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, true
+}
+
+// normalizeOperator returns the operator in upper case with single spaces and whether it is one of operators.
+func normalizeOperator(operator string) (string, bool) {
+	normalized := strings.Join(strings.Fields(strings.ToUpper(operator)), " ")
+	return normalized, operators[normalized]
+}
+
+func (c conjunction) writeCondition(w *sqlWriter) error {
+	written := 0
+	for _, child := range c.conditions {
+		if isEmptyCondition(child) {
+			continue
+		}
+		child = unwrapCondition(child)
+		if written > 0 {
+			w.WriteString(" " + c.operator + " ")
+		}
+		grouped := false
+		switch cc := child.(type) {
+		case conjunction:
+			grouped = cc.operator != c.operator
+		case Expr:
+			grouped = len(c.conditions) > 1
+		}
+		if grouped {
+			w.WriteString("(")
+		}
+		if err := child.writeCondition(w); err != nil {
+			return err
+		}
+		if grouped {
+			w.WriteString(")")
+		}
+		written++
+	}
+	return nil
+}
+
+func (c negation) writeCondition(w *sqlWriter) error {
+	w.WriteString("NOT (")
+	if err := c.condition.writeCondition(w); err != nil {
+		return err
+	}
+	w.WriteString(")")
+	return nil
+}
+
+func (c exists) writeCondition(w *sqlWriter) error {
+	if c.not {
+		w.WriteString("NOT ")
+	}
+	w.WriteString("EXISTS ")
+	return w.writeSubquery(c.query)
+}
+
+func (e Expr) writeCondition(w *sqlWriter) error {
+	return w.writeExpr(e)
+}
+
+func isEmptyCondition(c Condition) bool {
+	switch cc := c.(type) {
+	case nil:
+		return true
+	case conjunction:
+		for _, child := range cc.conditions {
+			if !isEmptyCondition(child) {
+				return false
+			}
+		}
+		return true
+	case negation:
+		return isEmptyCondition(cc.condition)
+	case Expr:
+		// a blank Raw() would be written as WHERE with nothing after it
+		return strings.TrimSpace(cc.sql) == ""
+	}
+	return false
+}
+
+func unwrapCondition(c Condition) Condition {
+	cc, ok := c.(conjunction)
+	if !ok {
+		return c
+	}
+	var single Condition
+	for _, child := range cc.conditions {
+		if isEmptyCondition(child) {
+			continue
+		}
+		if single != nil {
+			return c
+		}
+		single = child
+	}
+	return unwrapCondition(single)
+}
+
+// joinConditions combines the conditions built so far with the next one, so every
+// Where() call applies to everything added before it.
+func joinConditions(operator string, left Condition, right Condition) Condition {
+	if isEmptyCondition(left) {
+		return right
+	}
+	if isEmptyCondition(right) {
+		return left
+	}
+	left, right = unwrapCondition(left), unwrapCondition(right)
+	if c, ok := left.(conjunction); ok && c.operator == operator {
+		conditions := make([]Condition, len(c.conditions), len(c.conditions)+1)
+		copy(conditions, c.conditions)
+		return conjunction{operator, append(conditions, right)}
+	}
+	return conjunction{operator, []Condition{left, right}}
+}
+
+func (w *sqlWriter) writeWhere(where Condition) error {
+	if isEmptyCondition(where) {
+		return nil
+	}
+	w.WriteString(" WHERE ")
+	return unwrapCondition(where).writeCondition(w)
+}
@@ -0,0 +1,222 @@
+package sqlq
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCondition_WriteCondition(t *testing.T) {
+	tables := []struct {
+		Condition Condition
+		Output    string
+		Args      []interface{}
+	}{
+		{
+			Cond("id", "=", 5),
+			"id = ?",
+			[]interface{}{5},
+		},
+		{
+			Cond("deleted_at", "=", nil),
+			"deleted_at IS NULL",
+			nil,
+		},
+		{
+			Cond("deleted_at", "<>", nil),
+			"deleted_at IS NOT NULL",
+			nil,
+		},
+		{
+			Cond("deleted_at", "!=", sql.NullTime{}),
+			"deleted_at IS NOT NULL",
+			nil,
+		},
+		{
+			And(Cond("a", "=", 1), Or(Cond("b", "=", 2), Cond("c", "=", 3))),
+			"a = ? AND (b = ? OR c = ?)",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			Or(And(Cond("a", "=", 1), Cond("b", "=", 2)), Cond("c", "=", 3)),
+			"(a = ? AND b = ?) OR c = ?",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			And(Cond("a", "=", 1), And(Cond("b", "=", 2), Cond("c", "=", 3))),
+			"a = ? AND b = ? AND c = ?",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			And(Cond("a", "=", 1), And(Or(Cond("b", "=", 2), Cond("c", "=", 3)))),
+			"a = ? AND (b = ? OR c = ?)",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			And(Cond("a", "=", 1), Or(), And()),
+			"a = ?",
+			[]interface{}{1},
+		},
+		{
+			Not(Or(Cond("a", "=", 1), Cond("b", "=", 2))),
+			"NOT (a = ? OR b = ?)",
+			[]interface{}{1, 2},
+		},
+		{
+			And(Cond("a", "=", 1), Raw(""), Or(Raw(" "))),
+			"a = ?",
+			[]interface{}{1},
+		},
+		{
+			And(Cond("a", "=", 1), Raw("b = ? OR c = ?", 2, 3)),
+			"a = ? AND (b = ? OR c = ?)",
+			[]interface{}{1, 2, 3},
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(MySQL, false)
+		err := table.Condition.writeCondition(w)
+		if w.String() != table.Output || !reflect.DeepEqual(w.args, table.Args) || err != nil {
+			t.Errorf("Expected %v %v got %v %v %v", table.Output, table.Args, w.String(), w.args, err)
+		}
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	tables := []struct {
+		Dialect   Dialect
+		Condition Condition
+		Output    string
+		Args      []interface{}
+		Err       error
+	}{
+		{MySQL, Eq("id", 5), "id = ?", []interface{}{5}, nil},
+		{MySQL, Eq("deleted_at", nil), "deleted_at IS NULL", nil, nil},
+		{MySQL, NotEq("id", 5), "id <> ?", []interface{}{5}, nil},
+		{MySQL, NotEq("deleted_at", nil), "deleted_at IS NOT NULL", nil, nil},
+		{MySQL, Lt("age", 18), "age < ?", []interface{}{18}, nil},
+		{MySQL, Lte("age", 18), "age <= ?", []interface{}{18}, nil},
+		{MySQL, Gt("age", 18), "age > ?", []interface{}{18}, nil},
+		{MySQL, Gte("age", Col("min_age")), "age >= min_age", nil, nil},
+		{MySQL, Like("name", "s%"), "name LIKE ?", []interface{}{"s%"}, nil},
+		{MySQL, NotLike("name", "s%"), "name NOT LIKE ?", []interface{}{"s%"}, nil},
+		{PostgreSQL, ILike("name", "s%"), "name ILIKE $1", []interface{}{"s%"}, nil},
+		{SQLite, ILike("name", "s%"), "LOWER(name) LIKE LOWER(?)", []interface{}{"s%"}, nil},
+		{SQLServer, Cond("name", "not ilike", "s%"), "LOWER(name) NOT LIKE LOWER(@p1)", []interface{}{"s%"}, nil},
+		{MySQL, In("id", []interface{}{1, 2, 3}), "id IN (?, ?, ?)", []interface{}{1, 2, 3}, nil},
+		{PostgreSQL, NotIn("id", []interface{}{1, 2}), "id NOT IN ($1, $2)", []interface{}{1, 2}, nil},
+		{MySQL, In("id", Select("user_id").From("orders")), "id IN (SELECT user_id FROM orders)", nil, nil},
+		{MySQL, Cond("id", "in", []interface{}{1}), "id IN (?)", []interface{}{1}, nil},
+		{MySQL, Between("age", 18, 65), "age BETWEEN ? AND ?", []interface{}{18, 65}, nil},
+		{MySQL, Not(Between("age", 18, 65)), "NOT (age BETWEEN ? AND ?)", []interface{}{18, 65}, nil},
+		{MySQL, Cond("age", "NOT  BETWEEN", []interface{}{18, 65}), "age NOT BETWEEN ? AND ?", []interface{}{18, 65}, nil},
+		{MySQL, IsNull("deleted_at"), "deleted_at IS NULL", nil, nil},
+		{MySQL, IsNotNull("deleted_at"), "deleted_at IS NOT NULL", nil, nil},
+		{MySQL, In("id", []int{4, 5}), "id IN (?, ?)", []interface{}{4, 5}, nil},
+		{PostgreSQL, NotIn("name", []string{"a", "b"}), "name NOT IN ($1, $2)", []interface{}{"a", "b"}, nil},
+		{MySQL, In("id", [2]int64{6, 7}), "id IN (?, ?)", []interface{}{int64(6), int64(7)}, nil},
+		{MySQL, Cond("id", "IN", []uint{8}), "id IN (?)", []interface{}{uint(8)}, nil},
+		{MySQL, In("id", []int{}), "1 = 0", nil, nil},
+		{MySQL, In("id", []interface{}{}), "1 = 0", nil, nil},
+		{MySQL, NotIn("id", []string(nil)), "1 = 1", nil, nil},
+		{MySQL, Cond("age", "BETWEEN", []int{18, 65}), "age BETWEEN ? AND ?", []interface{}{18, 65}, nil},
+		{MySQL, In("data", []byte("ab")), "", nil, ErrConditionInValues},
+		{MySQL, Eq("data", []byte("ab")), "data = ?", []interface{}{[]byte("ab")}, nil},
+		{MySQL, Eq("id", []int{1, 2}), "", nil, ErrConditionValueList},
+		{MySQL, In("id", 5), "", nil, ErrConditionInValues},
+		{MySQL, Cond("age", "BETWEEN", []interface{}{18}), "", nil, ErrConditionBetweenValues},
+		{MySQL, Cond("age", "BETWEEN", 18), "", nil, ErrConditionBetweenValues},
+		{MySQL, Eq("id", []interface{}{1, 2}), "", nil, ErrConditionValueList},
+		{MySQL, Cond("id", "=>", 1), "", nil, ErrConditionOperatorInvalid},
+		{MySQL, Cond("id", "= 1 OR 1 =", 1), "", nil, ErrConditionOperatorInvalid},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(table.Dialect, false)
+		err := w.finish(table.Condition.writeCondition(w))
+		if table.Err != nil {
+			if !errors.Is(err, table.Err) {
+				t.Errorf("Expected error %v got %v", table.Err, err)
+			}
+			continue
+		}
+		if w.String() != table.Output || !reflect.DeepEqual(w.args, table.Args) || err != nil {
+			t.Errorf("%v: Expected %v %v got %v %v %v", table.Dialect.Name(), table.Output, table.Args, w.String(), w.args, err)
+		}
+	}
+}
+
+func TestJoinConditions(t *testing.T) {
+	a, b, c := Cond("a", "=", 1), Cond("b", "=", 2), Cond("c", "=", 3)
+	tables := []struct {
+		Condition Condition
+		Output    string
+	}{
+		{
+			joinConditions("AND", nil, a),
+			" WHERE a = ?",
+		},
+		{
+			joinConditions("OR", joinConditions("AND", a, b), c),
+			" WHERE (a = ? AND b = ?) OR c = ?",
+		},
+		{
+			joinConditions("AND", joinConditions("OR", a, b), c),
+			" WHERE (a = ? OR b = ?) AND c = ?",
+		},
+		{
+			joinConditions("OR", a, And(b)),
+			" WHERE a = ? OR b = ?",
+		},
+		{
+			joinConditions("AND", a, And()),
+			" WHERE a = ?",
+		},
+		{
+			nil,
+			"",
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(MySQL, false)
+		err := w.writeWhere(table.Condition)
+		if w.String() != table.Output || err != nil {
+			t.Errorf("Expected %v got %v %v", table.Output, w.String(), err)
+		}
+	}
+}
+
+func TestJoinConditions_DoesNotShareConditions(t *testing.T) {
+	base := joinConditions("AND", Cond("a", "=", 1), Cond("b", "=", 2))
+	first := joinConditions("AND", base, Cond("c", "=", 3))
+	second := joinConditions("AND", base, Cond("d", "=", 4))
+	if reflect.DeepEqual(first, second) {
+		t.Errorf("Expected conditions built from the same base to differ")
+	}
+}
+
+func TestExists(t *testing.T) {
+	tables := []struct {
+		Condition Condition
+		Output    string
+		Args      []interface{}
+	}{
+		{
+			Exists(Select("1").From("orders").Where("orders.user_id", "=", Col("users.id")).Where("status", "=", "paid")),
+			"EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id AND status = $1)",
+			[]interface{}{"paid"},
+		},
+		{
+			NotExists(Select("1").From("bans").Where("bans.user_id", "=", Col("users.id"))),
+			"NOT EXISTS (SELECT 1 FROM bans WHERE bans.user_id = users.id)",
+			nil,
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(PostgreSQL, false)
+		err := table.Condition.writeCondition(w)
+		if w.String() != table.Output || !reflect.DeepEqual(w.args, table.Args) || err != nil {
+			t.Errorf("Expected %v %v got %v %v %v", table.Output, table.Args, w.String(), w.args, err)
+		}
+	}
+}
@@ -2,15 +2,26 @@ package sqlq
 
 import (
 	"errors"
-	"strings"
+	"strconv"
 )
 
-var errDeleteEmptyTable = errors.New("\nTable Name is required to do Delete Operation\nUse From() function to specify Table Name")
+var ErrDeleteEmptyTable = errors.New("Table Name is required to do Delete Operation, use From() function to specify Table Name")
+var ErrDeleteWithoutWhere = errors.New("Delete without Where removes every row, use Where() to specify rows or AllRows() to delete every row")
+var ErrDeleteJoinUnsupported = errors.New("Dialect doesn't support Join in Delete")
+var ErrDeleteLimitUnsupported = errors.New("Dialect doesn't support Order By and Limit in Delete")
+var ErrDeleteJoinLimit = errors.New("Delete with Join can't have Order By or Limit")
 
 type DeleteBuilder struct {
-	table         string
-	conditionsAnd []string
-	conditionsOr  []string
+	with      withClause
+	table     string
+	joins     []join
+	where     Condition
+	order     []orderBy
+	paging    pagination
+	allRows   bool
+	returning []string
+	check     validation
+	dialect   Dialect
 }
 
 func (db *DeleteBuilder) From(table string) *DeleteBuilder {
@@ -18,40 +29,188 @@ func (db *DeleteBuilder) From(table string) *DeleteBuilder {
 	return db
 }
 
-func (db *DeleteBuilder) Where(column string, operator string, value string) *DeleteBuilder {
-	if column != "" && operator != "" && value != "" {
-		db.conditionsAnd = append(db.conditionsAnd, column+" "+operator+" '"+value+"'")
+func (db *DeleteBuilder) Join(table interface{}, on ...Condition) *DeleteBuilder {
+	db.joins = append(db.joins, join{"INNER JOIN", table, on})
+	return db
+}
+
+func (db *DeleteBuilder) LeftJoin(table interface{}, on ...Condition) *DeleteBuilder {
+	db.joins = append(db.joins, join{"LEFT JOIN", table, on})
+	return db
+}
+
+func (db *DeleteBuilder) CrossJoin(table interface{}) *DeleteBuilder {
+	db.joins = append(db.joins, join{"CROSS JOIN", table, nil})
+	return db
+}
+
+func (db *DeleteBuilder) Where(column interface{}, operator string, value interface{}) *DeleteBuilder {
+	if db.check.allowCondition("WHERE", column, operator, value) {
+		db.where = joinConditions("AND", db.where, condition{column, operator, value})
 	}
 	return db
 }
 
-func (db *DeleteBuilder) WhereOr(column string, operator string, value string) *DeleteBuilder {
-	if column != "" && operator != "" && value != "" {
-		db.conditionsOr = append(db.conditionsOr, column+" "+operator+" '"+value+"'")
+func (db *DeleteBuilder) WhereOr(column interface{}, operator string, value interface{}) *DeleteBuilder {
+	if db.check.allowCondition("WHERE", column, operator, value) {
+		db.where = joinConditions("OR", db.where, condition{column, operator, value})
 	}
 	return db
 }
 
+func (db *DeleteBuilder) WhereCond(conditions ...Condition) *DeleteBuilder {
+	db.where = joinConditions("AND", db.where, And(conditions...))
+	return db
+}
+
+func (db *DeleteBuilder) WhereOrCond(conditions ...Condition) *DeleteBuilder {
+	db.where = joinConditions("OR", db.where, And(conditions...))
+	return db
+}
+
+// AllRows allows the delete to run without Where, which removes every row of the table.
+func (db *DeleteBuilder) AllRows() *DeleteBuilder {
+	db.allRows = true
+	return db
+}
+
+func (db *DeleteBuilder) OrderBy(column interface{}, order string) *DeleteBuilder {
+	db.order = append(db.order, orderBy{column, order})
+	return db
+}
+
+// Limit deletes at most limit rows, with OrderBy() it deletes in bounded batches.
+func (db *DeleteBuilder) Limit(limit int) *DeleteBuilder {
+	db.paging.setLimit(limit)
+	return db
+}
+
+func (db *DeleteBuilder) With(name string, query interface{}, columns ...string) *DeleteBuilder {
+	db.with.add(false, name, query, columns)
+	return db
+}
+
+// WithRecursive adds a common table expression that can reference itself, it makes the whole WITH recursive.
+func (db *DeleteBuilder) WithRecursive(name string, query interface{}, columns ...string) *DeleteBuilder {
+	db.with.add(true, name, query, columns)
+	return db
+}
+
+func (db *DeleteBuilder) Dialect(dialect Dialect) *DeleteBuilder {
+	db.dialect = dialect
+	return db
+}
+
 func (db *DeleteBuilder) Sql() (string, error) {
-	var err error
-	if db.table == "" {
-		err = errDeleteEmptyTable
+	w := newSqlWriter(db.dialect, true)
+	if err := db.write(w); err != nil {
 		return "", err
 	}
-	sql := "DELETE FROM " + db.table
-	if len(db.conditionsAnd) > 0 || len(db.conditionsOr) > 0 {
-		sql += " WHERE "
+	return w.String(), nil
+}
+
+func (db *DeleteBuilder) ToSql() (string, []interface{}, error) {
+	w := newSqlWriter(db.dialect, false)
+	if err := db.write(w); err != nil {
+		return "", nil, err
+	}
+	return w.String(), w.args, nil
+}
+
+func (db *DeleteBuilder) write(w *sqlWriter) (err error) {
+	defer w.enter("DELETE", &err)()
+	if err := db.validate(w.dialect); err != nil {
+		return err
+	}
+
+	w.clause = "WITH"
+	if err := w.writeWith(db.with); err != nil {
+		return err
+	}
+	where := db.where
+	switch {
+	case len(db.joins) <= 0:
+		w.clause = "FROM"
+		w.WriteString("DELETE FROM ")
+		w.writeIdent(db.table)
+		w.clause = "RETURNING"
+		if err := w.writeOutput(db.returning, "DELETED"); err != nil {
+			return err
+		}
+	case w.dialect.Supports(FeatureDeleteJoin):
+		w.clause = "FROM"
+		w.WriteString("DELETE ")
+		w.writeIdent(db.table)
+		w.clause = "RETURNING"
+		if err := w.writeOutput(db.returning, "DELETED"); err != nil {
+			return err
+		}
+		w.clause = "FROM"
+		w.WriteString(" FROM ")
+		w.writeIdent(db.table)
+		w.clause = "JOIN"
+		if err := w.writeJoins(db.joins); err != nil {
+			return err
+		}
+	case w.dialect.Supports(FeatureDeleteUsing):
+		// the deleted table can't be joined, so the first table goes in USING and its join condition in WHERE
+		w.clause = "FROM"
+		w.WriteString("DELETE FROM ")
+		w.writeIdent(db.table)
+		w.clause = "JOIN"
+		w.WriteString(" USING ")
+		on, err := w.writeFirstJoinTable(db.joins[0])
+		if err != nil {
+			return err
+		}
+		if err := w.writeJoins(db.joins[1:]); err != nil {
+			return err
+		}
+		where = joinConditions("AND", And(on...), where)
+	default:
+		w.clause = "JOIN"
+		return ErrDeleteJoinUnsupported
+	}
+	w.clause = "WHERE"
+	if err := w.writeWhere(where); err != nil {
+		return err
+	}
+	// SQLite writes RETURNING before ORDER BY and LIMIT
+	w.clause = "RETURNING"
+	if err := w.writeReturning(db.returning); err != nil {
+		return err
+	}
+	w.clause = "ORDER BY"
+	if len(db.order) > 0 {
+		w.WriteString(" ")
+		if err := w.writeOrderBy(db.order); err != nil {
+			return err
+		}
+	}
+	if db.paging.limited {
+		w.WriteString(" LIMIT " + strconv.Itoa(db.paging.limit))
+	}
+	return nil
+}
+
+func (db *DeleteBuilder) validate(dialect Dialect) error {
+	p := problems{statement: "DELETE"}
+	p.addAll(db.check.errs)
+	if db.table == "" {
+		p.add(&ValidationError{Clause: "FROM", Err: ErrDeleteEmptyTable})
 	}
-	if len(db.conditionsAnd) > 0 {
-		sql += strings.Join(db.conditionsAnd, " AND ")
+	if isEmptyCondition(db.where) && !db.allRows {
+		p.add(&ValidationError{Clause: "WHERE", Err: ErrDeleteWithoutWhere})
 	}
-	if len(db.conditionsOr) > 0 {
-		if len(db.conditionsAnd) > 0 {
-			sql += " OR"
+	db.paging.check(&p)
+	if len(db.order) > 0 || db.paging.limited {
+		if len(db.joins) > 0 {
+			p.add(&ValidationError{Clause: "LIMIT", Err: ErrDeleteJoinLimit})
+		} else if !dialect.Supports(FeatureDeleteLimit) {
+			p.add(&ValidationError{Clause: "LIMIT", Err: ErrDeleteLimitUnsupported})
 		}
-		sql += " " + strings.Join(db.conditionsOr, " OR ")
 	}
-	return sql, err
+	return p.err()
 }
 
 func Delete() *DeleteBuilder {
@@ -1,6 +1,7 @@
 package sqlq
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -168,8 +169,8 @@ func TestDeleteBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			[]string{},
-			"DELETE FROM users",
-			nil,
+			"",
+			ErrDeleteWithoutWhere,
 		},
 		{
 			"",
@@ -180,7 +181,7 @@ func TestDeleteBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errDeleteEmptyTable,
+			ErrDeleteEmptyTable,
 		},
 	}
 	for _, table := range tables {
@@ -192,9 +193,171 @@ func TestDeleteBuilder_Sql(t *testing.T) {
 			delete.Where(table.ConditionColumnsOr[i], table.ConditionOperatorsOr[i], table.ConditionValuesOr[i])
 		}
 		result, err := delete.Sql()
-		if result != table.Output && err != table.Error {
+		if result != table.Output && !errors.Is(err, table.Error) {
 			t.Errorf("Expected %v\nGot %v\n", table.Output, result)
 			t.Errorf("Expected error %v\nGot %v\n", table.Error, err)
 		}
 	}
 }
+
+func TestDeleteBuilder_ToSql(t *testing.T) {
+	tables := []struct {
+		Builder *DeleteBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Delete().From("users").Where("id", "=", "3").Where("name", "=", "O'Brien"),
+			"DELETE FROM users WHERE id = ? AND name = ?",
+			[]interface{}{"3", "O'Brien"},
+			nil,
+		},
+		{
+			Delete(),
+			"",
+			nil,
+			ErrDeleteEmptyTable,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestDeleteBuilder_SqlRaw(t *testing.T) {
+	result, err := Delete().From("sessions").Where("expires_at", "<", Raw("CURRENT_DATE")).Sql()
+	if result != "DELETE FROM sessions WHERE expires_at < CURRENT_DATE" || err != nil {
+		t.Errorf("Got %v %v", result, err)
+	}
+}
+
+func TestDeleteBuilder_WhereCond(t *testing.T) {
+	result, err := Delete().From("sessions").Where("user_id", "=", 3).
+		WhereCond(Or(Cond("expired", "=", true), Cond("revoked", "=", true))).Sql()
+	if result != "DELETE FROM sessions WHERE user_id = 3 AND (expired = TRUE OR revoked = TRUE)" || err != nil {
+		t.Errorf("Got %v %v", result, err)
+	}
+}
+
+func TestDeleteBuilder_With(t *testing.T) {
+	result, args, err := Delete().From("sessions").Where("user_id", "IN", Select("id").From("banned")).
+		With("banned", Select("id").From("users").Where("banned", "=", true)).Dialect(SQLite).ToSql()
+	if result != "WITH banned AS (SELECT id FROM users WHERE banned = ?) DELETE FROM sessions WHERE user_id IN (SELECT id FROM banned)" ||
+		!reflect.DeepEqual(args, []interface{}{true}) || err != nil {
+		t.Errorf("Got %v %v %v", result, args, err)
+	}
+}
+
+func TestDeleteBuilder_Join(t *testing.T) {
+	sessions := func() *DeleteBuilder {
+		return Delete().From("sessions").Join("users", On("users.id", "=", "sessions.user_id")).Where("users.banned", "=", true)
+	}
+	tables := []struct {
+		Builder *DeleteBuilder
+		Dialect Dialect
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			sessions(),
+			MySQL,
+			"DELETE sessions FROM sessions INNER JOIN users ON users.id = sessions.user_id WHERE users.banned = ?",
+			[]interface{}{true},
+			nil,
+		},
+		{
+			sessions().Returning("id"),
+			SQLServer,
+			"DELETE sessions OUTPUT DELETED.id FROM sessions INNER JOIN users ON users.id = sessions.user_id WHERE users.banned = @p1",
+			[]interface{}{true},
+			nil,
+		},
+		{
+			sessions().LeftJoin("teams", On("teams.id", "=", "users.team_id")).Returning("id"),
+			PostgreSQL,
+			"DELETE FROM sessions USING users LEFT JOIN teams ON teams.id = users.team_id " +
+				"WHERE users.id = sessions.user_id AND users.banned = $1 RETURNING id",
+			[]interface{}{true},
+			nil,
+		},
+		{sessions(), SQLite, "", nil, ErrDeleteJoinUnsupported},
+		{Delete().From("sessions").LeftJoin("users", On("users.id", "=", "sessions.user_id")).AllRows(), PostgreSQL, "", nil, ErrJoinFirst},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.Dialect(table.Dialect).ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v %v\nGot %v %v %v\n", table.Dialect.Name(), table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestDeleteBuilder_OrderByLimit(t *testing.T) {
+	logs := func() *DeleteBuilder {
+		return Delete().From("logs").Where("created_at", "<", "2020-01-01").OrderBy("id", "ASC").Limit(1000)
+	}
+	tables := []struct {
+		Builder *DeleteBuilder
+		Dialect Dialect
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			logs(),
+			MySQL,
+			"DELETE FROM logs WHERE created_at < ? ORDER BY id ASC LIMIT 1000",
+			[]interface{}{"2020-01-01"},
+			nil,
+		},
+		{
+			logs().Returning("id"),
+			SQLite,
+			"DELETE FROM logs WHERE created_at < ? RETURNING id ORDER BY id ASC LIMIT 1000",
+			[]interface{}{"2020-01-01"},
+			nil,
+		},
+		{
+			Delete().From("logs").AllRows().Limit(0),
+			MySQL,
+			"DELETE FROM logs LIMIT 0",
+			nil,
+			nil,
+		},
+		{logs(), PostgreSQL, "", nil, ErrDeleteLimitUnsupported},
+		{logs(), SQLServer, "", nil, ErrDeleteLimitUnsupported},
+		{logs().Join("users", On("users.id", "=", "logs.user_id")), MySQL, "", nil, ErrDeleteJoinLimit},
+		{Delete().From("logs").AllRows().Limit(-1), MySQL, "", nil, ErrSelectLimitNegative},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.Dialect(table.Dialect).ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v %v\nGot %v %v %v\n", table.Dialect.Name(), table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestDeleteBuilder_AllRows(t *testing.T) {
+	tables := []struct {
+		Builder *DeleteBuilder
+		Output  string
+		Error   error
+	}{
+		{Delete().From("users"), "", ErrDeleteWithoutWhere},
+		{Delete().From("users").Strict(false).Where("id", "=", ""), "", ErrDeleteWithoutWhere},
+		{Delete().From("users").Join("banned", On("banned.user_id", "=", "users.id")), "", ErrDeleteWithoutWhere},
+		{Delete().From("users").WhereCond(Raw("")), "", ErrDeleteWithoutWhere},
+		{Delete().From("users").WhereCond(Raw(""), Not(Raw("  "))).WhereOrCond(Raw("\n")), "", ErrDeleteWithoutWhere},
+		{Delete().From("users").AllRows(), "DELETE FROM users", nil},
+	}
+	for _, table := range tables {
+		result, err := table.Builder.Sql()
+		if result != table.Output || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v\nGot %v %v\n", table.Output, table.Error, result, err)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package sqlq
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PagingStyle int
+
+const (
+	// LIMIT n OFFSET m
+	PagingLimitOffset PagingStyle = iota
+	// SELECT TOP n, or OFFSET m ROWS FETCH NEXT n ROWS ONLY when there is an offset
+	PagingTop
+	// OFFSET m ROWS FETCH FIRST n ROWS ONLY
+	PagingFetchFirst
+	// SELECT TOP n, or ROW_NUMBER() filtered in a derived table when there is an offset
+	PagingRowNumber
+)
+
+type Feature int
+
+const (
+	// WITH RECURSIVE instead of WITH for recursive common table expressions
+	FeatureRecursiveKeyword Feature = iota
+	// WITH written before INSERT INTO
+	FeatureInsertWith
+	// VALUES used as a query on its own
+	FeatureValuesQuery
+	// VALUES ROW(...) instead of VALUES (...) when VALUES is used as a query
+	FeatureValuesRow
+	// window functions with OVER (PARTITION BY ... ORDER BY ...)
+	FeatureWindow
+	// named window definitions with WINDOW name AS (...)
+	FeatureWindowClause
+	// ROWS BETWEEN and RANGE BETWEEN window frames
+	FeatureWindowFrame
+	// RANGE window frames with n PRECEDING or n FOLLOWING
+	FeatureWindowRangeOffset
+	// upserts with INSERT ... ON CONFLICT (...) DO NOTHING or DO UPDATE SET
+	FeatureOnConflict
+	// ON CONFLICT ON CONSTRAINT name
+	FeatureOnConflictConstraint
+	// upserts with INSERT ... ON DUPLICATE KEY UPDATE
+	FeatureOnDuplicateKey
+	// upserts with MERGE INTO ... USING ... WHEN MATCHED
+	FeatureMerge
+	// RETURNING columns at the end of INSERT, UPDATE and DELETE
+	FeatureReturning
+	// OUTPUT INSERTED.column or DELETED.column in INSERT, UPDATE and DELETE
+	FeatureOutput
+	// UPDATE t JOIN ... SET
+	FeatureUpdateJoin
+	// UPDATE t SET ... FROM joined tables, the first join condition goes in WHERE
+	FeatureUpdateFrom
+	// UPDATE t SET ... FROM t JOIN ...
+	FeatureUpdateFromJoin
+	// DELETE t FROM t JOIN ...
+	FeatureDeleteJoin
+	// DELETE FROM t USING joined tables, the first join condition goes in WHERE
+	FeatureDeleteUsing
+	// ORDER BY and LIMIT on a single table DELETE, SQLite needs SQLITE_ENABLE_UPDATE_DELETE_LIMIT
+	FeatureDeleteLimit
+	// case insensitive ILIKE, other dialects compare LOWER() of both sides with LIKE
+	FeatureILike
+	// INSERT ... SELECT with ON CONFLICT needs a WHERE in the SELECT, or ON is read as a join constraint
+	FeatureUpsertSelectWhere
+)
+
+type Dialect interface {
+	Name() string
+	Placeholder(position int) string
+	QuoteIdent(name string) string
+	BoolLiteral(value bool) string
+	StringLiteral(value string) string
+	TimeLiteral(value time.Time) string
+	BytesLiteral(value []byte) string
+	PagingStyle() PagingStyle
+	// LimitAll is written as LIMIT when a select has an offset but no limit, empty to leave LIMIT out.
+	LimitAll() string
+	// MaxPlaceholders is the most bound parameters one statement can have.
+	MaxPlaceholders() int
+	// MaxInsertRows is the most rows one INSERT ... VALUES can have, 0 for no limit.
+	MaxInsertRows() int
+	// IsReserved reports whether word is a keyword that is quoted when it is used as a table or column name.
+	IsReserved(word string) bool
+	Supports(feature Feature) bool
+}
+
+var (
+	MySQL      Dialect = mysqlDialect{}
+	PostgreSQL Dialect = postgresDialect{}
+	SQLite     Dialect = sqliteDialect{}
+	SQLServer  Dialect = sqlserverDialect{}
+	// SQLServer2008 pages with ROW_NUMBER() because OFFSET FETCH is not available before SQL Server 2012
+	SQLServer2008 Dialect = sqlserverDialect{legacy: true}
+)
+
+var defaultDialect = MySQL
+
+// SetDefaultDialect changes the dialect used by builders that were not given one with Dialect().
+// It is not safe to call concurrently with rendering, so call it during initialization.
+func SetDefaultDialect(dialect Dialect) {
+	if dialect != nil {
+		defaultDialect = dialect
+	}
+}
+
+func DefaultDialect() Dialect {
+	return defaultDialect
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string {
+	return "mysql"
+}
+
+func (mysqlDialect) Placeholder(position int) string {
+	return "?"
+}
+
+func (mysqlDialect) QuoteIdent(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+func (mysqlDialect) BoolLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (mysqlDialect) StringLiteral(value string) string {
+	return quoteString(strings.Replace(value, `\`, `\\`, -1))
+}
+
+func (mysqlDialect) TimeLiteral(value time.Time) string {
+	return "'" + value.Format("2006-01-02 15:04:05.999999") + "'"
+}
+
+func (mysqlDialect) BytesLiteral(value []byte) string {
+	return "X'" + hex.EncodeToString(value) + "'"
+}
+
+func (mysqlDialect) PagingStyle() PagingStyle {
+	return PagingLimitOffset
+}
+
+func (mysqlDialect) LimitAll() string {
+	return "18446744073709551615"
+}
+
+func (mysqlDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+func (mysqlDialect) MaxInsertRows() int {
+	return 0
+}
+
+func (mysqlDialect) IsReserved(word string) bool {
+	return mysqlReserved[strings.ToUpper(word)]
+}
+
+func (mysqlDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureRecursiveKeyword, FeatureValuesQuery, FeatureValuesRow,
+		FeatureWindow, FeatureWindowClause, FeatureWindowFrame, FeatureWindowRangeOffset,
+		FeatureOnDuplicateKey, FeatureUpdateJoin, FeatureDeleteJoin, FeatureDeleteLimit:
+		return true
+	}
+	return false
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string {
+	return "postgres"
+}
+
+func (postgresDialect) Placeholder(position int) string {
+	return "$" + strconv.Itoa(position)
+}
+
+func (postgresDialect) QuoteIdent(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (postgresDialect) BoolLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (postgresDialect) StringLiteral(value string) string {
+	return quoteString(value)
+}
+
+func (postgresDialect) TimeLiteral(value time.Time) string {
+	return "'" + value.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+}
+
+func (postgresDialect) BytesLiteral(value []byte) string {
+	return `'\x` + hex.EncodeToString(value) + "'"
+}
+
+func (postgresDialect) PagingStyle() PagingStyle {
+	return PagingLimitOffset
+}
+
+func (postgresDialect) LimitAll() string {
+	return "ALL"
+}
+
+func (postgresDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+func (postgresDialect) MaxInsertRows() int {
+	return 0
+}
+
+func (postgresDialect) IsReserved(word string) bool {
+	return postgresReserved[strings.ToUpper(word)]
+}
+
+func (postgresDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureRecursiveKeyword, FeatureInsertWith, FeatureValuesQuery,
+		FeatureWindow, FeatureWindowClause, FeatureWindowFrame, FeatureWindowRangeOffset,
+		FeatureOnConflict, FeatureOnConflictConstraint, FeatureReturning, FeatureUpdateFrom, FeatureDeleteUsing,
+		FeatureILike:
+		return true
+	}
+	return false
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return "sqlite"
+}
+
+func (sqliteDialect) Placeholder(position int) string {
+	return "?"
+}
+
+func (sqliteDialect) QuoteIdent(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (sqliteDialect) BoolLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func (sqliteDialect) StringLiteral(value string) string {
+	return quoteString(value)
+}
+
+func (sqliteDialect) TimeLiteral(value time.Time) string {
+	return "'" + value.Format("2006-01-02 15:04:05.999999999-07:00") + "'"
+}
+
+func (sqliteDialect) BytesLiteral(value []byte) string {
+	return "X'" + hex.EncodeToString(value) + "'"
+}
+
+func (sqliteDialect) PagingStyle() PagingStyle {
+	return PagingLimitOffset
+}
+
+func (sqliteDialect) LimitAll() string {
+	return "-1"
+}
+
+// MaxPlaceholders is the SQLITE_MAX_VARIABLE_NUMBER default before SQLite 3.32, newer versions allow 32766.
+func (sqliteDialect) MaxPlaceholders() int {
+	return 999
+}
+
+func (sqliteDialect) MaxInsertRows() int {
+	return 0
+}
+
+func (sqliteDialect) IsReserved(word string) bool {
+	return sqliteReserved[strings.ToUpper(word)]
+}
+
+func (sqliteDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureRecursiveKeyword, FeatureInsertWith, FeatureValuesQuery,
+		FeatureWindow, FeatureWindowClause, FeatureWindowFrame, FeatureWindowRangeOffset,
+		FeatureOnConflict, FeatureReturning, FeatureUpdateFrom, FeatureDeleteLimit, FeatureUpsertSelectWhere:
+		return true
+	}
+	return false
+}
+
+type sqlserverDialect struct {
+	legacy bool
+}
+
+func (sqlserverDialect) Name() string {
+	return "sqlserver"
+}
+
+func (sqlserverDialect) Placeholder(position int) string {
+	return "@p" + strconv.Itoa(position)
+}
+
+func (sqlserverDialect) QuoteIdent(name string) string {
+	return "[" + strings.Replace(name, "]", "]]", -1) + "]"
+}
+
+func (sqlserverDialect) BoolLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func (sqlserverDialect) StringLiteral(value string) string {
+	return "N" + quoteString(value)
+}
+
+func (sqlserverDialect) TimeLiteral(value time.Time) string {
+	return "'" + value.Format("2006-01-02T15:04:05.9999999") + "'"
+}
+
+func (sqlserverDialect) BytesLiteral(value []byte) string {
+	return "0x" + hex.EncodeToString(value)
+}
+
+func (d sqlserverDialect) PagingStyle() PagingStyle {
+	if d.legacy {
+		return PagingRowNumber
+	}
+	return PagingTop
+}
+
+func (sqlserverDialect) LimitAll() string {
+	return ""
+}
+
+func (sqlserverDialect) MaxPlaceholders() int {
+	return 2100
+}
+
+// MaxInsertRows is the limit of the VALUES table value constructor, a MERGE source has none.
+func (sqlserverDialect) MaxInsertRows() int {
+	return 1000
+}
+
+func (sqlserverDialect) IsReserved(word string) bool {
+	return sqlserverReserved[strings.ToUpper(word)]
+}
+
+func (d sqlserverDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureInsertWith, FeatureWindow, FeatureMerge, FeatureOutput, FeatureUpdateFromJoin, FeatureDeleteJoin:
+		return true
+	case FeatureWindowFrame:
+		return !d.legacy
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package sqlq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDialect_Placeholder(t *testing.T) {
+	tables := []struct {
+		Dialect  Dialect
+		Position int
+		Output   string
+	}{
+		{MySQL, 2, "?"},
+		{PostgreSQL, 2, "$2"},
+		{SQLite, 2, "?"},
+		{SQLServer, 2, "@p2"},
+	}
+	for _, table := range tables {
+		result := table.Dialect.Placeholder(table.Position)
+		if result != table.Output {
+			t.Errorf("%v: Expected %v got %v", table.Dialect.Name(), table.Output, result)
+		}
+	}
+}
+
+func TestDialect_QuoteIdent(t *testing.T) {
+	tables := []struct {
+		Dialect Dialect
+		Name    string
+		Output  string
+	}{
+		{MySQL, "order", "`order`"},
+		{MySQL, "a`b", "`a``b`"},
+		{PostgreSQL, "order", `"order"`},
+		{PostgreSQL, `a"b`, `"a""b"`},
+		{SQLite, "group", `"group"`},
+		{SQLServer, "order", "[order]"},
+		{SQLServer, "a]b", "[a]]b]"},
+	}
+	for _, table := range tables {
+		result := table.Dialect.QuoteIdent(table.Name)
+		if result != table.Output {
+			t.Errorf("%v: Expected %v got %v", table.Dialect.Name(), table.Output, result)
+		}
+	}
+}
+
+func TestDialect_BoolLiteral(t *testing.T) {
+	tables := []struct {
+		Dialect Dialect
+		Value   bool
+		Output  string
+	}{
+		{MySQL, true, "TRUE"},
+		{PostgreSQL, false, "FALSE"},
+		{SQLite, true, "1"},
+		{SQLServer, false, "0"},
+	}
+	for _, table := range tables {
+		result := table.Dialect.BoolLiteral(table.Value)
+		if result != table.Output {
+			t.Errorf("%v: Expected %v got %v", table.Dialect.Name(), table.Output, result)
+		}
+	}
+}
+
+type fetchFirstDialect struct {
+	postgresDialect
+}
+
+func (fetchFirstDialect) PagingStyle() PagingStyle {
+	return PagingFetchFirst
+}
+
+func TestDialect_Select(t *testing.T) {
+	tables := []struct {
+		Dialect Dialect
+		Output  string
+		Args    []interface{}
+	}{
+		{
+			MySQL,
+			"SELECT id FROM users WHERE id > ? AND name = ? ORDER BY id ASC LIMIT 5",
+			[]interface{}{"1", "sqlq"},
+		},
+		{
+			PostgreSQL,
+			"SELECT id FROM users WHERE id > $1 AND name = $2 ORDER BY id ASC LIMIT 5",
+			[]interface{}{"1", "sqlq"},
+		},
+		{
+			SQLite,
+			"SELECT id FROM users WHERE id > ? AND name = ? ORDER BY id ASC LIMIT 5",
+			[]interface{}{"1", "sqlq"},
+		},
+		{
+			SQLServer,
+			"SELECT TOP 5 id FROM users WHERE id > @p1 AND name = @p2 ORDER BY id ASC",
+			[]interface{}{"1", "sqlq"},
+		},
+		{
+			fetchFirstDialect{},
+			"SELECT id FROM users WHERE id > $1 AND name = $2 ORDER BY id ASC FETCH FIRST 5 ROWS ONLY",
+			[]interface{}{"1", "sqlq"},
+		},
+	}
+	for _, table := range tables {
+		result, args, err := Select("id").From("users").Where("id", ">", "1").Where("name", "=", "sqlq").
+			OrderBy("id", "ASC").Limit(5).Dialect(table.Dialect).ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || err != nil {
+			t.Errorf("%v: Expected %v %v got %v %v %v", table.Dialect.Name(), table.Output, table.Args, result, args, err)
+		}
+	}
+}
+
+func TestSetDefaultDialect(t *testing.T) {
+	defer SetDefaultDialect(DefaultDialect())
+
+	SetDefaultDialect(PostgreSQL)
+	result, _, _ := Delete().From("users").Where("id", "=", "1").ToSql()
+	if result != "DELETE FROM users WHERE id = $1" {
+		t.Errorf("Expected postgres placeholders got %v", result)
+	}
+	result, _, _ = Delete().From("users").Where("id", "=", "1").Dialect(SQLServer).ToSql()
+	if result != "DELETE FROM users WHERE id = @p1" {
+		t.Errorf("Expected builder dialect to win got %v", result)
+	}
+
+	SetDefaultDialect(nil)
+	if DefaultDialect() != PostgreSQL {
+		t.Errorf("Expected nil dialect to be ignored got %v", DefaultDialect().Name())
+	}
+}
+
+func TestDialect_Supports(t *testing.T) {
+	tables := []struct {
+		Dialect Dialect
+		Feature Feature
+		Output  bool
+	}{
+		{MySQL, FeatureRecursiveKeyword, true},
+		{SQLServer, FeatureRecursiveKeyword, false},
+		{MySQL, FeatureInsertWith, false},
+		{PostgreSQL, FeatureInsertWith, true},
+		{SQLServer, FeatureValuesQuery, false},
+		{MySQL, FeatureValuesRow, true},
+		{SQLite, FeatureValuesRow, false},
+		{SQLite, FeatureOnConflictConstraint, false},
+		{MySQL, FeatureOnDuplicateKey, true},
+		{PostgreSQL, FeatureILike, true},
+		{SQLite, FeatureILike, false},
+		{SQLite, FeatureUpsertSelectWhere, true},
+		{PostgreSQL, FeatureUpsertSelectWhere, false},
+		{SQLServer2008, FeatureMerge, true},
+		{MySQL, FeatureReturning, false},
+		{SQLServer, FeatureOutput, true},
+	}
+	for _, table := range tables {
+		result := table.Dialect.Supports(table.Feature)
+		if result != table.Output {
+			t.Errorf("%v: Expected %v for feature %v got %v", table.Dialect.Name(), table.Output, table.Feature, result)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package sqlq
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ValidationError is returned by Sql() and ToSql() for a mistake found in a builder before or while it is written,
+// use errors.Is with the Err sentinels like ErrSelectEmptyTable or errors.As to get the details.
+// Several mistakes are returned together as an errors.Join of ValidationErrors.
+type ValidationError struct {
+	// Statement is SELECT, INSERT, UPDATE or DELETE
+	Statement string
+	// Clause is the part of the statement that is wrong, like FROM, COLUMNS, VALUES, SET or WHERE
+	Clause string
+	// Columns are the columns at fault, like the duplicated ones
+	Columns []string
+	// Row is the wrong row of an insert starting from 1, 0 when the error isn't about a row
+	Row int
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	var parts []string
+	if e.Statement != "" {
+		parts = append(parts, e.Statement)
+	}
+	if e.Clause != "" {
+		parts = append(parts, e.Clause)
+	}
+	if e.Row > 0 {
+		parts = append(parts, "row "+strconv.Itoa(e.Row))
+	}
+	if len(e.Columns) > 0 {
+		parts = append(parts, "("+strings.Join(e.Columns, ", ")+")")
+	}
+	if len(parts) <= 0 {
+		return e.Err.Error()
+	}
+	return strings.Join(parts, " ") + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// problems collects every ValidationError of a statement so they can be returned at once.
+type problems struct {
+	statement string
+	errs      []error
+}
+
+func (p *problems) add(e *ValidationError) {
+	if e.Statement == "" {
+		e.Statement = p.statement
+	}
+	p.errs = append(p.errs, e)
+}
+
+func (p *problems) addAll(errs []*ValidationError) {
+	for _, e := range errs {
+		copied := *e
+		p.add(&copied)
+	}
+}
+
+func (p problems) err() error {
+	switch len(p.errs) {
+	case 0:
+		return nil
+	case 1:
+		return p.errs[0]
+	}
+	return errors.Join(p.errs...)
+}
+
+// enter starts writing a statement, which can be a subquery of another one, use it as
+// defer w.enter("SELECT", &err)() so the returned func turns err into a ValidationError of the statement.
+// When the outermost statement ends, err also gets the problems recorded while writing.
+func (w *sqlWriter) enter(statement string, err *error) func() {
+	outerStatement, outerClause := w.statement, w.clause
+	w.statement, w.clause = statement, ""
+	w.depth++
+	return func() {
+		if *err != nil {
+			*err = w.locate(*err)
+		}
+		w.statement, w.clause = outerStatement, outerClause
+		w.depth--
+		if w.depth == 0 {
+			*err = w.finish(*err)
+		}
+	}
+}
+
+// locate makes err a ValidationError of the statement and clause being written, errors that are
+// ValidationErrors of a statement already or several joined ones are kept as they are.
+func (w *sqlWriter) locate(err error) error {
+	if _, ok := err.(interface{ Unwrap() []error }); ok {
+		return err
+	}
+	e, ok := err.(*ValidationError)
+	if !ok {
+		return &ValidationError{Statement: w.statement, Clause: w.clause, Err: err}
+	} else if e.Statement != "" {
+		return e
+	}
+	located := *e
+	located.Statement = w.statement
+	if located.Clause == "" {
+		located.Clause = w.clause
+	}
+	return &located
+}
+
+// record keeps a mistake that doesn't stop the writing, like an invalid name, so it is returned
+// together with the others.
+func (w *sqlWriter) record(err error) {
+	w.problems = append(w.problems, w.locate(err))
+}
+
+// finish returns the recorded problems and err at once.
+func (w *sqlWriter) finish(err error) error {
+	p := problems{errs: append([]error{}, w.problems...)}
+	if err != nil {
+		p.errs = append(p.errs, err)
+	}
+	return p.err()
+}
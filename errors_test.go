@@ -0,0 +1,121 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	tables := []struct {
+		Error  *ValidationError
+		Output string
+	}{
+		{
+			&ValidationError{Statement: "SELECT", Clause: "FROM", Err: ErrSelectEmptyTable},
+			"SELECT FROM: " + ErrSelectEmptyTable.Error(),
+		},
+		{
+			&ValidationError{Statement: "INSERT", Clause: "VALUES", Row: 3, Err: ErrInsertColumnsValuesDiffLen},
+			"INSERT VALUES row 3: Length of Columns and Values must be the same",
+		},
+		{
+			&ValidationError{Statement: "UPDATE", Clause: "SET", Columns: []string{"name", "email"}, Err: ErrUpdateColumnsSame},
+			"UPDATE SET (name, email): You have same Columns in your query",
+		},
+		{
+			&ValidationError{Columns: []string{"id;"}, Err: ErrIdentInvalid},
+			"(id;): " + ErrIdentInvalid.Error(),
+		},
+	}
+	for _, table := range tables {
+		if table.Error.Error() != table.Output || !errors.Is(table.Error, table.Error.Err) {
+			t.Errorf("Expected %v\nGot %v\n", table.Output, table.Error.Error())
+		}
+	}
+}
+
+func TestValidationError_Aggregate(t *testing.T) {
+	_, err := Insert().Columns("id", "name", "id").Values(1, "a", 2).Values(3).Sql()
+	for _, sentinel := range []error{ErrInsertEmptyTable, ErrInsertColumnsSame, ErrInsertColumnsValuesDiffLen} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("Expected %v in\n%v\n", sentinel, err)
+		}
+	}
+	if errors.Is(err, ErrInsertEmptyValues) {
+		t.Errorf("Unexpected %v in\n%v\n", ErrInsertEmptyValues, err)
+	}
+
+	var found []ValidationError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var validationErr *ValidationError
+		if errors.As(e, &validationErr) {
+			found = append(found, *validationErr)
+		}
+	}
+	expected := []ValidationError{
+		{"INSERT", "INTO", nil, 0, ErrInsertEmptyTable},
+		{"INSERT", "COLUMNS", []string{"id"}, 0, ErrInsertColumnsSame},
+		{"INSERT", "VALUES", nil, 2, ErrInsertColumnsValuesDiffLen},
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %v\nGot %v\n", expected, found)
+	}
+
+	_, err = Select().Where("", "=", 1).Limit(-1).Sql()
+	for _, sentinel := range []error{ErrConditionEmptyColumn, ErrSelectEmptyTable, ErrSelectEmptyColumns, ErrSelectLimitNegative} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("Expected %v in\n%v\n", sentinel, err)
+		}
+	}
+}
+
+func TestValidationError_Writing(t *testing.T) {
+	tables := []struct {
+		Query    interface{ Sql() (string, error) }
+		Expected []ValidationError
+	}{
+		{
+			Select("id").From("users").Join("orders"),
+			[]ValidationError{{"SELECT", "JOIN", nil, 0, ErrJoinEmptyCondition}},
+		},
+		{
+			Select("id;", "name").From("users").Where("age", ">", 18).GroupBy("name)").OrderBy("name", "up"),
+			[]ValidationError{
+				{"SELECT", "COLUMNS", []string{"id;"}, 0, ErrIdentInvalid},
+				{"SELECT", "GROUP BY", []string{"name)"}, 0, ErrIdentInvalid},
+				{"SELECT", "ORDER BY", nil, 0, ErrOrderInvalid},
+			},
+		},
+		{
+			Update("users").Set("name--", "a").Where("id", "IN", Select("id").From("t t2")),
+			[]ValidationError{
+				{"UPDATE", "SET", []string{"name--"}, 0, ErrIdentInvalid},
+				{"SELECT", "FROM", []string{"t t2"}, 0, ErrIdentInvalid},
+			},
+		},
+	}
+	for _, table := range tables {
+		_, err := table.Query.Sql()
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		}
+		var found []ValidationError
+		for _, e := range errs {
+			var validationErr *ValidationError
+			if errors.As(e, &validationErr) {
+				found = append(found, *validationErr)
+			}
+		}
+		if !reflect.DeepEqual(found, table.Expected) {
+			t.Errorf("Expected %v\nGot %v\n", table.Expected, found)
+		}
+	}
+
+	_, err := Insert().Into("users").Columns("id", "na me").Values(1, "a").Values(2, "b").Batches(0)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Statement != "INSERT" || validationErr.Clause != "COLUMNS" {
+		t.Errorf("Expected INSERT COLUMNS error got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package sqlq
+
+import (
+	"errors"
+)
+
+var ErrExprArgsCount = errors.New("Number of placeholders in Raw() expression must be the same as number of args")
+
+type Expr struct {
+	sql  string
+	args []interface{}
+}
+
+// Raw creates an expression that is written into the query as it is.
+// Use ? for args, they are written as placeholders of the dialect, ?? writes a literal question mark.
+func Raw(sql string, args ...interface{}) Expr {
+	return Expr{sql: sql, args: args}
+}
+
+func (w *sqlWriter) writeExpr(expr Expr) error {
+	arg := 0
+	for i := 0; i < len(expr.sql); i++ {
+		c := expr.sql[i]
+		switch {
+		case c == '\'':
+			end := i + 1
+			for end < len(expr.sql) && expr.sql[end] != '\'' {
+				end++
+			}
+			if end < len(expr.sql) {
+				end++
+			}
+			w.WriteString(expr.sql[i:end])
+			i = end - 1
+		case c == '?' && i+1 < len(expr.sql) && expr.sql[i+1] == '?':
+			w.WriteByte('?')
+			i++
+		case c == '?':
+			if arg >= len(expr.args) {
+				return ErrExprArgsCount
+			}
+			if err := w.writeValue(expr.args[arg]); err != nil {
+				return err
+			}
+			arg++
+		default:
+			w.WriteByte(c)
+		}
+	}
+	if arg != len(expr.args) {
+		return ErrExprArgsCount
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRaw(t *testing.T) {
+	tables := []struct {
+		Sql    string
+		Args   []interface{}
+		Output Expr
+	}{
+		{
+			"NOW()",
+			nil,
+			Expr{sql: "NOW()"},
+		},
+		{
+			"hits + ?",
+			[]interface{}{1},
+			Expr{sql: "hits + ?", args: []interface{}{1}},
+		},
+	}
+	for _, table := range tables {
+		result := Raw(table.Sql, table.Args...)
+		if !reflect.DeepEqual(result, table.Output) {
+			t.Errorf("Expected %v got %v", table.Output, result)
+		}
+	}
+}
+
+func TestSqlWriter_WriteExpr(t *testing.T) {
+	tables := []struct {
+		Dialect Dialect
+		Inline  bool
+		Expr    Expr
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			MySQL,
+			false,
+			Raw("NOW()"),
+			"NOW()",
+			nil,
+			nil,
+		},
+		{
+			PostgreSQL,
+			false,
+			Raw("hits + ? * ?", 1, 2),
+			"hits + $1 * $2",
+			[]interface{}{1, 2},
+			nil,
+		},
+		{
+			MySQL,
+			true,
+			Raw("CONCAT(name, ?)", "O'Brien"),
+			"CONCAT(name, 'O''Brien')",
+			nil,
+			nil,
+		},
+		{
+			PostgreSQL,
+			false,
+			Raw("data ?? 'key?' AND id = ?", 1),
+			"data ? 'key?' AND id = $1",
+			[]interface{}{1},
+			nil,
+		},
+		{
+			PostgreSQL,
+			false,
+			Raw("COALESCE(?, ?)", Raw("NOW()"), 1),
+			"COALESCE(NOW(), $1)",
+			[]interface{}{1},
+			nil,
+		},
+		{
+			MySQL,
+			false,
+			Raw("id = ?"),
+			"id = ",
+			nil,
+			ErrExprArgsCount,
+		},
+		{
+			MySQL,
+			false,
+			Raw("id = 1", 1),
+			"id = 1",
+			nil,
+			ErrExprArgsCount,
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(table.Dialect, table.Inline)
+		err := w.writeExpr(table.Expr)
+		if w.String() != table.Output || !reflect.DeepEqual(w.args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v got %v %v %v", table.Output, table.Args, table.Error, w.String(), w.args, err)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package sqlq
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrIdentInvalid = errors.New("Identifier must be a name of letters, digits and _ separated by dots, quote it to use other characters")
+var ErrOrderInvalid = errors.New("Order must be ASC or DESC")
+
+var identRegexp = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_$]*$`)
+var numberRegexp = regexp.MustCompile(`^[0-9]+$`)
+
+// mysqlReserved are the reserved words of MySQL 8, keywords that are not reserved stay unquoted.
+var mysqlReserved = reservedWords(`
+	ACCESSIBLE ADD ALL ALTER ANALYZE AND AS ASC ASENSITIVE BEFORE BETWEEN BIGINT BINARY BLOB BOTH BY CALL
+	CASCADE CASE CHANGE CHAR CHARACTER CHECK COLLATE COLUMN CONDITION CONSTRAINT CONTINUE CONVERT CREATE CROSS
+	CUBE CUME_DIST CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP CURRENT_USER CURSOR DATABASE DATABASES DAY_HOUR
+	DAY_MICROSECOND DAY_MINUTE DAY_SECOND DEC DECIMAL DECLARE DEFAULT DELAYED DELETE DENSE_RANK DESC DESCRIBE
+	DETERMINISTIC DISTINCT DISTINCTROW DIV DOUBLE DROP DUAL EACH ELSE ELSEIF EMPTY ENCLOSED ESCAPED EXCEPT
+	EXISTS EXIT EXPLAIN FALSE FETCH FIRST_VALUE FLOAT FLOAT4 FLOAT8 FOR FORCE FOREIGN FROM FULLTEXT FUNCTION
+	GENERATED GET GRANT GROUP GROUPING GROUPS HAVING HIGH_PRIORITY HOUR_MICROSECOND HOUR_MINUTE HOUR_SECOND
+	IF IGNORE IN INDEX INFILE INNER INOUT INSENSITIVE INSERT INT INT1 INT2 INT3 INT4 INT8 INTEGER INTERSECT
+	INTERVAL INTO IO_AFTER_GTIDS IO_BEFORE_GTIDS IS ITERATE JOIN JSON_TABLE KEY KEYS KILL LAG LAST_VALUE
+	LATERAL LEAD LEADING LEAVE LEFT LIKE LIMIT LINEAR LINES LOAD LOCALTIME LOCALTIMESTAMP LOCK LONG LONGBLOB
+	LONGTEXT LOOP LOW_PRIORITY MASTER_BIND MASTER_SSL_VERIFY_SERVER_CERT MATCH MAXVALUE MEDIUMBLOB MEDIUMINT
+	MEDIUMTEXT MIDDLEINT MINUTE_MICROSECOND MINUTE_SECOND MOD MODIFIES NATURAL NOT NO_WRITE_TO_BINLOG
+	NTH_VALUE NTILE NULL NUMERIC OF ON OPTIMIZE OPTIMIZER_COSTS OPTION OPTIONALLY OR ORDER OUT OUTER OUTFILE
+	OVER PARTITION PERCENT_RANK PRECISION PRIMARY PROCEDURE PURGE RANGE RANK READ READS READ_WRITE REAL
+	RECURSIVE REFERENCES REGEXP RELEASE RENAME REPEAT REPLACE REQUIRE RESIGNAL RESTRICT RETURN REVOKE RIGHT
+	RLIKE ROW ROWS ROW_NUMBER SCHEMA SCHEMAS SECOND_MICROSECOND SELECT SENSITIVE SEPARATOR SET SHOW SIGNAL
+	SMALLINT SPATIAL SPECIFIC SQL SQLEXCEPTION SQLSTATE SQLWARNING SQL_BIG_RESULT SQL_CALC_FOUND_ROWS
+	SQL_SMALL_RESULT SSL STARTING STORED STRAIGHT_JOIN SYSTEM TABLE TERMINATED THEN TINYBLOB TINYINT TINYTEXT
+	TO TRAILING TRIGGER TRUE UNDO UNION UNIQUE UNLOCK UNSIGNED UPDATE USAGE USE USING UTC_DATE UTC_TIME
+	UTC_TIMESTAMP VALUES VARBINARY VARCHAR VARCHARACTER VARYING VIRTUAL WHEN WHERE WHILE WINDOW WITH WRITE XOR
+	YEAR_MONTH ZEROFILL`)
+
+// postgresReserved are the reserved keywords of PostgreSQL, including the ones that can be a function or type.
+// Other keywords must stay unquoted because a quoted name is not folded to lower case.
+var postgresReserved = reservedWords(`
+	ALL ANALYSE ANALYZE AND ANY ARRAY AS ASC ASYMMETRIC AUTHORIZATION BINARY BOTH CASE CAST CHECK COLLATE
+	COLLATION COLUMN CONCURRENTLY CONSTRAINT CREATE CROSS CURRENT_CATALOG CURRENT_DATE CURRENT_ROLE
+	CURRENT_SCHEMA CURRENT_TIME CURRENT_TIMESTAMP CURRENT_USER DEFAULT DEFERRABLE DESC DISTINCT DO ELSE END
+	EXCEPT FALSE FETCH FOR FOREIGN FREEZE FROM FULL GRANT GROUP HAVING ILIKE IN INITIALLY INNER INTERSECT INTO
+	IS ISNULL JOIN LATERAL LEADING LEFT LIKE LIMIT LOCALTIME LOCALTIMESTAMP NATURAL NOT NOTNULL NULL OFFSET ON
+	ONLY OR ORDER OUTER OVERLAPS PLACING PRIMARY REFERENCES RETURNING RIGHT SELECT SESSION_USER SIMILAR SOME
+	SYMMETRIC SYSTEM_USER TABLE TABLESAMPLE THEN TO TRAILING TRUE UNION UNIQUE USER USING VARIADIC VERBOSE
+	WHEN WHERE WINDOW WITH`)
+
+// sqliteReserved are the keywords of SQLite.
+var sqliteReserved = reservedWords(`
+	ABORT ACTION ADD AFTER ALL ALTER ALWAYS ANALYZE AND AS ASC ATTACH AUTOINCREMENT BEFORE BEGIN BETWEEN BY
+	CASCADE CASE CAST CHECK COLLATE COLUMN COMMIT CONFLICT CONSTRAINT CREATE CROSS CURRENT CURRENT_DATE
+	CURRENT_TIME CURRENT_TIMESTAMP DATABASE DEFAULT DEFERRABLE DEFERRED DELETE DESC DETACH DISTINCT DO DROP
+	EACH ELSE END ESCAPE EXCEPT EXCLUDE EXCLUSIVE EXISTS EXPLAIN FAIL FILTER FIRST FOLLOWING FOR FOREIGN FROM
+	FULL GENERATED GLOB GROUP GROUPS HAVING IF IGNORE IMMEDIATE IN INDEX INDEXED INITIALLY INNER INSERT
+	INSTEAD INTERSECT INTO IS ISNULL JOIN KEY LAST LEFT LIKE LIMIT MATCH MATERIALIZED NATURAL NO NOT NOTHING
+	NOTNULL NULL NULLS OF OFFSET ON OR ORDER OTHERS OUTER OVER PARTITION PLAN PRAGMA PRECEDING PRIMARY QUERY
+	RAISE RANGE RECURSIVE REFERENCES REGEXP REINDEX RELEASE RENAME REPLACE RESTRICT RETURNING RIGHT ROLLBACK
+	ROW ROWS SAVEPOINT SELECT SET TABLE TEMP TEMPORARY THEN TIES TO TRANSACTION TRIGGER UNBOUNDED UNION
+	UNIQUE UPDATE USING VACUUM VALUES VIEW VIRTUAL WHEN WHERE WINDOW WITH WITHOUT`)
+
+// sqlserverReserved are the reserved keywords of Transact-SQL.
+var sqlserverReserved = reservedWords(`
+	ADD ALL ALTER AND ANY AS ASC AUTHORIZATION BACKUP BEGIN BETWEEN BREAK BROWSE BULK BY CASCADE CASE CHECK
+	CHECKPOINT CLOSE CLUSTERED COALESCE COLLATE COLUMN COMMIT COMPUTE CONSTRAINT CONTAINS CONTAINSTABLE
+	CONTINUE CONVERT CREATE CROSS CURRENT CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP CURRENT_USER CURSOR
+	DATABASE DBCC DEALLOCATE DECLARE DEFAULT DELETE DENY DESC DISK DISTINCT DISTRIBUTED DOUBLE DROP DUMP ELSE
+	END ERRLVL ESCAPE EXCEPT EXEC EXECUTE EXISTS EXIT EXTERNAL FETCH FILE FILLFACTOR FOR FOREIGN FREETEXT
+	FREETEXTTABLE FROM FULL FUNCTION GOTO GRANT GROUP HAVING HOLDLOCK IDENTITY IDENTITY_INSERT IDENTITYCOL IF
+	IN INDEX INNER INSERT INTERSECT INTO IS JOIN KEY KILL LEFT LIKE LINENO LOAD MERGE NATIONAL NOCHECK
+	NONCLUSTERED NOT NULL NULLIF OF OFF OFFSETS ON OPEN OPENDATASOURCE OPENQUERY OPENROWSET OPENXML OPTION OR
+	ORDER OUTER OVER PERCENT PIVOT PLAN PRECISION PRIMARY PRINT PROC PROCEDURE PUBLIC RAISERROR READ READTEXT
+	RECONFIGURE REFERENCES REPLICATION RESTORE RESTRICT RETURN REVERT REVOKE RIGHT ROLLBACK ROWCOUNT
+	ROWGUIDCOL RULE SAVE SCHEMA SECURITYAUDIT SELECT SEMANTICKEYPHRASETABLE SEMANTICSIMILARITYDETAILSTABLE
+	SEMANTICSIMILARITYTABLE SESSION_USER SET SETUSER SHUTDOWN SOME STATISTICS SYSTEM_USER TABLE TABLESAMPLE
+	TEXTSIZE THEN TO TOP TRAN TRANSACTION TRIGGER TRUNCATE TRY_CONVERT TSEQUAL UNION UNIQUE UNPIVOT UPDATE
+	UPDATETEXT USE USER VALUES VARYING VIEW WAITFOR WHEN WHERE WHILE WITH WITHIN WRITETEXT`)
+
+func reservedWords(list string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.Fields(list) {
+		words[word] = true
+	}
+	return words
+}
+
+// quoteIdent validates a name like table, schema.table or db.schema.table.column and quotes the parts
+// that are reserved words of the dialect or were quoted already, with the quotes of the dialect.
+// The last part can be * when star is true and there can be only one part when single is true.
+func (w *sqlWriter) quoteIdent(name string, star bool, single bool) (string, error) {
+	invalid := &ValidationError{Columns: []string{name}, Err: ErrIdentInvalid}
+	var b strings.Builder
+	for i := 0; i < len(name); {
+		if i > 0 {
+			if single || name[i] != '.' {
+				return "", invalid
+			}
+			b.WriteString(".")
+			i++
+		}
+		part, quoted, end := nextIdentPart(name, i)
+		if end < 0 {
+			return "", invalid
+		}
+		last := end == len(name)
+		switch {
+		case quoted:
+			b.WriteString(w.dialect.QuoteIdent(part))
+		case part == "*" && star && last:
+			b.WriteString(part)
+		case !identRegexp.MatchString(part):
+			return "", invalid
+		case w.dialect.IsReserved(part):
+			b.WriteString(w.dialect.QuoteIdent(part))
+		default:
+			b.WriteString(part)
+		}
+		i = end
+	}
+	if b.Len() <= 0 {
+		return "", invalid
+	}
+	return b.String(), nil
+}
+
+// nextIdentPart reads the part of name that starts at start, quoted with double quotes, backticks or brackets, or up to the next dot.
+// end is where the part stops or -1 when the quotes are not closed or the quoted part is empty.
+func nextIdentPart(name string, start int) (part string, quoted bool, end int) {
+	if start >= len(name) {
+		return "", false, -1
+	}
+	closing := byte(0)
+	switch name[start] {
+	case '"':
+		closing = '"'
+	case '`':
+		closing = '`'
+	case '[':
+		closing = ']'
+	default:
+		end = strings.IndexByte(name[start:], '.')
+		if end < 0 {
+			return name[start:], false, len(name)
+		}
+		return name[start : start+end], false, start + end
+	}
+	var b strings.Builder
+	for i := start + 1; i < len(name); i++ {
+		if name[i] != closing {
+			b.WriteByte(name[i])
+		} else if i+1 < len(name) && name[i+1] == closing {
+			// a doubled quote is a quote inside the name
+			b.WriteByte(closing)
+			i++
+		} else if b.Len() > 0 {
+			return b.String(), true, i + 1
+		} else {
+			break
+		}
+	}
+	return "", true, -1
+}
+
+// writeName writes the quoted name, an invalid name is recorded so the writing goes on to find other mistakes.
+func (w *sqlWriter) writeName(name string, star bool, single bool) {
+	quoted, err := w.quoteIdent(name, star, single)
+	if err != nil {
+		w.record(err)
+		return
+	}
+	w.WriteString(quoted)
+}
+
+func (w *sqlWriter) writeIdent(name string) {
+	w.writeName(name, false, false)
+}
+
+func (w *sqlWriter) writeIdents(names []string) {
+	for i, name := range names {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.writeIdent(name)
+	}
+}
+
+// writeAlias writes a name of a single part like an alias, a common table expression or a window.
+func (w *sqlWriter) writeAlias(name string) {
+	w.writeName(name, false, true)
+}
+
+func (w *sqlWriter) writeOrder(order string) {
+	switch strings.ToUpper(order) {
+	case "":
+	case "ASC", "DESC":
+		w.WriteString(" " + order)
+	default:
+		w.record(&ValidationError{Clause: "ORDER BY", Err: ErrOrderInvalid})
+	}
+}
@@ -0,0 +1,179 @@
+package sqlq
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSqlWriter_QuoteIdent(t *testing.T) {
+	tables := []struct {
+		Dialect Dialect
+		Name    string
+		Star    bool
+		Single  bool
+		Output  string
+		Err     error
+	}{
+		{MySQL, "users", false, false, "users", nil},
+		{MySQL, "order", false, false, "`order`", nil},
+		{PostgreSQL, "Group", false, false, `"Group"`, nil},
+		{SQLServer, "user", false, false, "[user]", nil},
+		{SQLite, "name", false, false, "name", nil},
+		{PostgreSQL, "db.public.users", false, false, "db.public.users", nil},
+		{PostgreSQL, "public.order.id", false, false, `public."order".id`, nil},
+		{MySQL, `"my table".id`, false, false, "`my table`.id", nil},
+		{SQLServer, "`weird``name`", false, false, "[weird`name]", nil},
+		{PostgreSQL, `[a]]b].c`, false, false, `"a]b".c`, nil},
+		{PostgreSQL, `"a""b"`, false, false, `"a""b"`, nil},
+		{PostgreSQL, "users.*", true, false, "users.*", nil},
+		{PostgreSQL, "*", true, false, "*", nil},
+		{PostgreSQL, "čaša_1$", false, false, "čaša_1$", nil},
+		{PostgreSQL, "users.*", false, false, "", ErrIdentInvalid},
+		{PostgreSQL, "*.id", true, false, "", ErrIdentInvalid},
+		{PostgreSQL, "users.id", false, true, "", ErrIdentInvalid},
+		{PostgreSQL, "", false, false, "", ErrIdentInvalid},
+		{PostgreSQL, "users.", false, false, "", ErrIdentInvalid},
+		{PostgreSQL, ".users", false, false, "", ErrIdentInvalid},
+		{PostgreSQL, "1users", false, false, "", ErrIdentInvalid},
+		{PostgreSQL, "id; DROP TABLE users", false, false, "", ErrIdentInvalid},
+		{PostgreSQL, "id DESC", false, false, "", ErrIdentInvalid},
+		{PostgreSQL, `"unclosed`, false, false, "", ErrIdentInvalid},
+		{PostgreSQL, `""`, false, false, "", ErrIdentInvalid},
+		{PostgreSQL, `"a"b`, false, false, "", ErrIdentInvalid},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(table.Dialect, false)
+		output, err := w.quoteIdent(table.Name, table.Star, table.Single)
+		if output != table.Output || !errors.Is(err, table.Err) {
+			t.Errorf("%v %v: Expected %v %v got %v %v", table.Dialect.Name(), table.Name, table.Output, table.Err, output, err)
+		}
+	}
+}
+
+func TestDialect_IsReserved(t *testing.T) {
+	tables := []struct {
+		Dialect Dialect
+		Name    string
+		Output  bool
+	}{
+		{MySQL, "order", true},
+		{PostgreSQL, "SELECT", true},
+		{SQLite, "Returning", true},
+		{SQLServer, "name", false},
+		{MySQL, "status", false},
+		{PostgreSQL, "orders", false},
+		{MySQL, "interval", true},
+		{MySQL, "READ", true},
+		{PostgreSQL, "interval", false},
+		{SQLServer, "plan", true},
+		{SQLServer2008, "Identity", true},
+		{MySQL, "identity", false},
+		{PostgreSQL, "lateral", true},
+		{PostgreSQL, "Key", false},
+		{PostgreSQL, "rank", false},
+		{MySQL, "rank", true},
+		{SQLServer, "lateral", false},
+	}
+	for _, table := range tables {
+		if output := table.Dialect.IsReserved(table.Name); output != table.Output {
+			t.Errorf("%v %v: Expected %v got %v", table.Dialect.Name(), table.Name, table.Output, output)
+		}
+	}
+}
+
+func TestBuilders_QuoteIdent(t *testing.T) {
+	tables := []struct {
+		Builder interface {
+			ToSql() (string, []interface{}, error)
+		}
+		Query string
+		Err   error
+	}{
+		{
+			Select("id", "group", "o.*").From(As("order", "o")).Where("key", "=", 1).OrderBy("desc", "DESC").Dialect(MySQL),
+			"SELECT id, `group`, o.* FROM `order` AS o WHERE `key` = ? ORDER BY `desc` DESC",
+			nil,
+		},
+		{
+			Select("id").From("sales.public.order").Join("user", On("user.id", "=", "order.user_id")).Dialect(PostgreSQL),
+			`SELECT id FROM sales.public."order" INNER JOIN "user" ON "user".id = "order".user_id`,
+			nil,
+		},
+		{
+			Insert().Into("order").Columns("id", "from").Values(1, "a").Returning("from").Dialect(SQLServer),
+			"INSERT INTO [order] (id, [from]) OUTPUT INSERTED.[from] VALUES (@p1, @p2)",
+			nil,
+		},
+		{
+			Insert().Into("key").Columns("id", "to").Values(1, "a").OnConflict("id").DoUpdateSet("to", Excluded("to")).Dialect(SQLite),
+			`INSERT INTO "key" (id, "to") VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET "to" = excluded."to"`,
+			nil,
+		},
+		{
+			Update("order").Set("limit", 1).Where("id", "=", 2).Dialect(PostgreSQL),
+			`UPDATE "order" SET "limit" = $1 WHERE id = $2`,
+			nil,
+		},
+		{
+			Delete().From("order").Where("id", "=", 2).Returning("*").Dialect(PostgreSQL),
+			`DELETE FROM "order" WHERE id = $1 RETURNING *`,
+			nil,
+		},
+		{
+			Select("id", "interval", "read").From("plans").Dialect(MySQL),
+			"SELECT id, `interval`, `read` FROM plans",
+			nil,
+		},
+		{
+			Select("id", "interval", "read").From("plan").Where("identity", "=", 1).Dialect(SQLServer),
+			"SELECT id, interval, [read] FROM [plan] WHERE [identity] = @p1",
+			nil,
+		},
+		{
+			Select("id", "lateral").From("plan").Dialect(PostgreSQL),
+			`SELECT id, "lateral" FROM plan`,
+			nil,
+		},
+		{
+			Select("Key", "Rank").From("Index").Where("Value", "=", 1).Dialect(PostgreSQL),
+			`SELECT Key, Rank FROM Index WHERE Value = $1`,
+			nil,
+		},
+		{
+			Select("id").From("users").OrderBy("name; DROP TABLE users", "ASC"),
+			"",
+			ErrIdentInvalid,
+		},
+		{
+			Select("id").From("users").OrderBy("name", "ASC; DROP TABLE users"),
+			"",
+			ErrOrderInvalid,
+		},
+		{
+			Select("id").From("users u"),
+			"",
+			ErrIdentInvalid,
+		},
+		{
+			Insert().Into("users").Columns("id) VALUES (1); --").Values(1),
+			"",
+			ErrIdentInvalid,
+		},
+		{
+			Update("users").Set("name = 'x'", 1).AllRows(),
+			"",
+			ErrIdentInvalid,
+		},
+		{
+			Delete().From("users WHERE 1 = 1").AllRows(),
+			"",
+			ErrIdentInvalid,
+		},
+	}
+	for _, table := range tables {
+		query, _, err := table.Builder.ToSql()
+		if query != table.Query || !errors.Is(err, table.Err) {
+			t.Errorf("Expected %v %v\nGot %v %v\n", table.Query, table.Err, query, err)
+		}
+	}
+}
@@ -2,21 +2,27 @@ package sqlq
 
 import (
 	"errors"
-	"fmt"
-	"strings"
 )
 
-var errInsertEmptyTable = errors.New("\nTable Name is required to do Insert Operation\nUse Into() function to specify Table Name")
-var errInsertEmptyColumns = errors.New("\nColumns is required to do Insert Operation\nUse Columns() function to specify Columns")
-var errInsertEmptyValues = errors.New("\nValues is required to do Insert Operation\nUse Values() function to specify Values")
+var ErrInsertEmptyTable = errors.New("Table Name is required to do Insert Operation, use Into() function to specify Table Name")
+var ErrInsertEmptyColumns = errors.New("Columns is required to do Insert Operation, use Columns() function to specify Columns")
+var ErrInsertEmptyValues = errors.New("Values is required to do Insert Operation, use Values() function to specify Values")
 
-var errInsertColumnsValuesDiffLen = errors.New("\nLength of Columns and Values must be the same")
-var errInsertColumnsSame = errors.New("You have same Columns in your query")
+var ErrInsertColumnsValuesDiffLen = errors.New("Length of Columns and Values must be the same")
+var ErrInsertColumnsSame = errors.New("You have same Columns in your query")
+var ErrInsertValuesAndSelect = errors.New("Insert can't have both Values() and FromSelect()")
+var ErrInsertSelectColumnsDiffLen = errors.New("Length of Columns and Select Columns must be the same")
 
 type InsertBuilder struct {
-	table   string
-	columns []string
-	values  []string
+	with      withClause
+	table     string
+	columns   []string
+	rows      [][]interface{}
+	query     *SelectBuilder
+	upsert    *upsert
+	returning []string
+	check     validation
+	dialect   Dialect
 }
 
 func (ib *InsertBuilder) Into(table string) *InsertBuilder {
@@ -29,33 +35,180 @@ func (ib *InsertBuilder) Columns(columns ...string) *InsertBuilder {
 	return ib
 }
 
-func (ib *InsertBuilder) Values(values ...string) *InsertBuilder {
-	for _, val := range values {
-		ib.values = append(ib.values, "'"+val+"'")
+// Values adds a row, call it again to insert more rows in one statement.
+func (ib *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
+	if len(values) > 0 {
+		ib.rows = append(ib.rows, values)
 	}
 	return ib
 }
 
+// FromSelect inserts the rows returned by query instead of Values().
+func (ib *InsertBuilder) FromSelect(query *SelectBuilder) *InsertBuilder {
+	ib.query = query
+	return ib
+}
+
+func (ib *InsertBuilder) With(name string, query interface{}, columns ...string) *InsertBuilder {
+	ib.with.add(false, name, query, columns)
+	return ib
+}
+
+// WithRecursive adds a common table expression that can reference itself, it makes the whole WITH recursive.
+func (ib *InsertBuilder) WithRecursive(name string, query interface{}, columns ...string) *InsertBuilder {
+	ib.with.add(true, name, query, columns)
+	return ib
+}
+
+func (ib *InsertBuilder) Dialect(dialect Dialect) *InsertBuilder {
+	ib.dialect = dialect
+	return ib
+}
+
 func (ib *InsertBuilder) Sql() (string, error) {
-	var err error
-	if ib.table == "" {
-		err = errInsertEmptyTable
-		return "", err
-	} else if len(ib.columns) <= 0 {
-		err = errInsertEmptyColumns
-		return "", err
-	} else if len(ib.values) <= 0 {
-		err = errInsertEmptyValues
-		return "", err
-	} else if len(ib.columns) != len(ib.values) {
-		err = errInsertColumnsValuesDiffLen
-		return "", err
-	} else if checkSameColumns(ib.columns) {
-		err = errInsertColumnsSame
+	w := newSqlWriter(ib.dialect, true)
+	if err := ib.write(w); err != nil {
 		return "", err
 	}
-	sql := "INSERT INTO " + ib.table + " (" + strings.Join(ib.columns, ", ") + ") VALUES (" + strings.Join(ib.values, ", ") + ")"
-	return sql, err
+	return w.String(), nil
+}
+
+func (ib *InsertBuilder) ToSql() (string, []interface{}, error) {
+	w := newSqlWriter(ib.dialect, false)
+	if err := ib.write(w); err != nil {
+		return "", nil, err
+	}
+	return w.String(), w.args, nil
+}
+
+func (ib *InsertBuilder) write(w *sqlWriter) (err error) {
+	defer w.enter("INSERT", &err)()
+	if err := ib.validate(); err != nil {
+		return err
+	}
+	if ib.query != nil {
+		return ib.writeSelect(w)
+	}
+	if err := ib.writeHead(w); err != nil {
+		return err
+	}
+	w.clause = "VALUES"
+	if err := w.writeRows(ib.rows, ""); err != nil {
+		return err
+	}
+	return ib.writeTail(w)
+}
+
+func (ib *InsertBuilder) validate() error {
+	p := problems{statement: "INSERT"}
+	p.addAll(ib.check.errs)
+	if ib.table == "" {
+		p.add(&ValidationError{Clause: "INTO", Err: ErrInsertEmptyTable})
+	}
+	if len(ib.columns) <= 0 {
+		p.add(&ValidationError{Clause: "COLUMNS", Err: ErrInsertEmptyColumns})
+	} else if duplicates := duplicateColumns(ib.columns); len(duplicates) > 0 {
+		p.add(&ValidationError{Clause: "COLUMNS", Columns: duplicates, Err: ErrInsertColumnsSame})
+	}
+	if len(ib.rows) <= 0 && ib.query == nil {
+		p.add(&ValidationError{Clause: "VALUES", Err: ErrInsertEmptyValues})
+	} else if len(ib.rows) > 0 && ib.query != nil {
+		p.add(&ValidationError{Clause: "VALUES", Err: ErrInsertValuesAndSelect})
+	}
+	if ib.query != nil && len(ib.columns) > 0 {
+		if count, ok := columnCount(ib.query.columns); ok && count != len(ib.columns) {
+			p.add(&ValidationError{Clause: "SELECT", Columns: ib.columns, Err: ErrInsertSelectColumnsDiffLen})
+		}
+	}
+	for i, row := range ib.rows {
+		if len(ib.columns) > 0 && len(row) != len(ib.columns) {
+			p.add(&ValidationError{Clause: "VALUES", Row: i + 1, Err: ErrInsertColumnsValuesDiffLen})
+		}
+	}
+	if ib.upsert != nil {
+		if err := ib.upsert.validate(); err != nil {
+			p.add(&ValidationError{Clause: "ON CONFLICT", Err: err})
+		}
+	}
+	return p.err()
+}
+
+// writeHead writes everything before the rows.
+func (ib *InsertBuilder) writeHead(w *sqlWriter) error {
+	w.clause = "WITH"
+	if len(ib.with.ctes) > 0 && !w.dialect.Supports(FeatureInsertWith) {
+		return ErrWithInsertUnsupported
+	}
+	if err := w.writeWith(ib.with); err != nil {
+		return err
+	}
+	if err := ib.writeInto(w); err != nil {
+		return err
+	}
+	w.WriteString("VALUES ")
+	return nil
+}
+
+func (ib *InsertBuilder) writeInto(w *sqlWriter) error {
+	w.clause = "INTO"
+	if ib.merging(w.dialect) {
+		w.WriteString("MERGE INTO ")
+		w.writeIdent(ib.table)
+		w.WriteString(" USING (")
+		return nil
+	}
+	w.WriteString("INSERT INTO ")
+	w.writeIdent(ib.table)
+	w.clause = "COLUMNS"
+	w.WriteString(" (")
+	w.writeIdents(ib.columns)
+	w.WriteString(")")
+	w.clause = "RETURNING"
+	if err := w.writeOutput(ib.returning, "INSERTED"); err != nil {
+		return err
+	}
+	w.WriteString(" ")
+	return nil
+}
+
+// writeSelect writes INSERT ... SELECT, dialects without WITH before INSERT get the WITH of both
+// merged in front of the SELECT.
+func (ib *InsertBuilder) writeSelect(w *sqlWriter) error {
+	w.clause = "WITH"
+	query := ib.query
+	if len(ib.with.ctes) > 0 && !w.dialect.Supports(FeatureInsertWith) {
+		merged := *query
+		merged.with = withClause{
+			recursive: ib.with.recursive || query.with.recursive,
+			ctes:      append(append([]cte{}, ib.with.ctes...), query.with.ctes...),
+		}
+		query = &merged
+	} else if err := w.writeWith(ib.with); err != nil {
+		return err
+	}
+	if ib.upsert != nil && isEmptyCondition(query.where) && w.dialect.Supports(FeatureUpsertSelectWhere) {
+		where := *query
+		where.where = Raw("true")
+		query = &where
+	}
+	if err := ib.writeInto(w); err != nil {
+		return err
+	}
+	w.clause = "SELECT"
+	if err := query.write(w); err != nil {
+		return err
+	}
+	return ib.writeTail(w)
+}
+
+// writeTail writes everything after the rows.
+func (ib *InsertBuilder) writeTail(w *sqlWriter) error {
+	w.clause = "ON CONFLICT"
+	if err := ib.writeUpsert(w); err != nil {
+		return err
+	}
+	w.clause = "RETURNING"
+	return w.writeReturning(ib.returning)
 }
 
 func Insert() *InsertBuilder {
@@ -1,8 +1,10 @@
 package sqlq
 
 import (
+	"errors"
 	"testing"
 	"reflect"
+	"time"
 )
 
 func TestInsert(t *testing.T) {
@@ -103,58 +105,58 @@ func TestInsertBuilder_Sql(t *testing.T) {
 	tables := []struct{
 		Table string
 		Columns []string
-		Values []string
+		Values []interface{}
 		Output string
 		Error error
 	}{
 		{
 			"users",
 			[]string{"email", "name", "password"},
-			[]string{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
+			[]interface{}{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
 			"INSERT INTO users (email, name, password) VALUES ('sqlq@valuppo.com', 'sqlq', 'sqlq_pass')",
 			nil,
 		},
 		{
 			"",
 			[]string{"email", "name", "password"},
-			[]string{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
+			[]interface{}{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
 			"",
-			errInsertEmptyTable,
+			ErrInsertEmptyTable,
 		},
 		{
 			"users",
 			[]string{""},
-			[]string{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
+			[]interface{}{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
 			"",
-			errInsertEmptyColumns,
+			ErrInsertEmptyColumns,
 		},
 		{
 			"users",
 			[]string{"email", "name", "password"},
-			[]string{""},
+			[]interface{}{""},
 			"",
-			errInsertEmptyValues,
+			ErrInsertEmptyValues,
 		},
 		{
 			"users",
 			[]string{"email", "name", "password"},
-			[]string{"'sqlq@valuppo.com'", "'sqlq'", "'sqlq_pass'", "'test'"},
+			[]interface{}{"'sqlq@valuppo.com'", "'sqlq'", "'sqlq_pass'", "'test'"},
 			"",
-			errInsertColumnsValuesDiffLen,
+			ErrInsertColumnsValuesDiffLen,
 		},
 		{
 			"users",
 			[]string{"email", "name", "password", "test"},
-			[]string{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
+			[]interface{}{"sqlq@valuppo.com", "sqlq", "sqlq_pass"},
 			"",
-			errInsertColumnsValuesDiffLen,
+			ErrInsertColumnsValuesDiffLen,
 		},
 		{
 			"users",
 			[]string{"email", "name", "password", "email"},
-			[]string{"sqlq@valuppo.com", "sqlq", "sqlq_pass", "test"},
+			[]interface{}{"sqlq@valuppo.com", "sqlq", "sqlq_pass", "test"},
 			"",
-			errInsertColumnsSame,
+			ErrInsertColumnsSame,
 		},
 	}
 	for _, table := range tables{
@@ -164,4 +166,170 @@ func TestInsertBuilder_Sql(t *testing.T) {
 			t.Errorf("Expected error %v\nGot %v\n", table.Error, err)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestInsertBuilder_ToSql(t *testing.T) {
+	tables := []struct {
+		Builder *InsertBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Insert().Into("users").Columns("name", "email").Values("O'Brien", "sqlq@valuppo.com"),
+			"INSERT INTO users (name, email) VALUES (?, ?)",
+			[]interface{}{"O'Brien", "sqlq@valuppo.com"},
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("name", "email").Values("sqlq"),
+			"",
+			nil,
+			ErrInsertColumnsValuesDiffLen,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestInsertBuilder_SqlTypedValues(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	result, args, err := Insert().Into("users").Columns("name", "age", "created_at", "avatar").
+		Values("sqlq", 30, created, []byte{0x01}).Dialect(PostgreSQL).ToSql()
+	if result != "INSERT INTO users (name, age, created_at, avatar) VALUES ($1, $2, $3, $4)" ||
+		!reflect.DeepEqual(args, []interface{}{"sqlq", 30, created, []byte{0x01}}) || err != nil {
+		t.Errorf("Got %v %v %v", result, args, err)
+	}
+
+	result, err = Insert().Into("users").Columns("name", "age", "created_at", "avatar").
+		Values("sqlq", 30, created, []byte{0x01}).Dialect(PostgreSQL).Sql()
+	if result != `INSERT INTO users (name, age, created_at, avatar) VALUES ('sqlq', 30, '2020-01-02 03:04:05+00:00', '\x01')` || err != nil {
+		t.Errorf("Got %v %v", result, err)
+	}
+}
+
+func TestInsertBuilder_SqlRaw(t *testing.T) {
+	result, err := Insert().Into("users").Columns("name", "created_at").Values("sqlq", Raw("NOW()")).Sql()
+	if result != "INSERT INTO users (name, created_at) VALUES ('sqlq', NOW())" || err != nil {
+		t.Errorf("Got %v %v", result, err)
+	}
+}
+
+func TestInsertBuilder_With(t *testing.T) {
+	builder := Insert().Into("logs").Columns("message").Values(Select("name").From("v")).
+		With("v", ValuesList().Row("started"), "name")
+	result, args, err := builder.Dialect(PostgreSQL).ToSql()
+	if result != "WITH v (name) AS (VALUES ($1)) INSERT INTO logs (message) VALUES ((SELECT name FROM v))" ||
+		!reflect.DeepEqual(args, []interface{}{"started"}) || err != nil {
+		t.Errorf("Got %v %v %v", result, args, err)
+	}
+	_, _, err = builder.Dialect(MySQL).ToSql()
+	if !errors.Is(err, ErrWithInsertUnsupported) {
+		t.Errorf("Expected %v got %v", ErrWithInsertUnsupported, err)
+	}
+}
+
+func TestInsertBuilder_MultipleRows(t *testing.T) {
+	tables := []struct {
+		Builder *InsertBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Insert().Into("users").Columns("name", "age").Values("a", 1).Values("b", 2).Values("c", 3),
+			"INSERT INTO users (name, age) VALUES (?, ?), (?, ?), (?, ?)",
+			[]interface{}{"a", 1, "b", 2, "c", 3},
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("name", "age").Values("a", 1).Values("b", 2).Dialect(PostgreSQL),
+			"INSERT INTO users (name, age) VALUES ($1, $2), ($3, $4)",
+			[]interface{}{"a", 1, "b", 2},
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("name", "age").Values("a", 1).Values("b").Values("c", 3),
+			"",
+			nil,
+			ErrInsertColumnsValuesDiffLen,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+
+	result, err := Insert().Into("users").Columns("name", "age").Values("a", 1).Values("O'Brien", 2).Sql()
+	if result != "INSERT INTO users (name, age) VALUES ('a', 1), ('O''Brien', 2)" || err != nil {
+		t.Errorf("Expected multi row insert\nGot %v %v\n", result, err)
+	}
+
+	_, err = Insert().Into("users").Columns("name", "age").Values("a", 1).Values("b").Sql()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Row != 2 || validationErr.Clause != "VALUES" {
+		t.Errorf("Expected error naming row 2\nGot %v\n", err)
+	}
+}
+
+func TestInsertBuilder_FromSelect(t *testing.T) {
+	live := func() *SelectBuilder {
+		return Select("a", "b").From("live").Where("created_at", "<", "2020-01-01")
+	}
+	tables := []struct {
+		Builder *InsertBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Insert().Into("archive").Columns("a", "b").FromSelect(live()),
+			"INSERT INTO archive (a, b) SELECT a, b FROM live WHERE created_at < ?",
+			[]interface{}{"2020-01-01"},
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("a", "b").FromSelect(Select("*").From("live")).Dialect(PostgreSQL),
+			"INSERT INTO archive (a, b) SELECT * FROM live",
+			nil,
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("a", "b").FromSelect(live().With("old", Select("id").From("t"))).
+				With("v", ValuesList().Row(1), "id").Dialect(PostgreSQL),
+			"WITH v (id) AS (VALUES ($1)) INSERT INTO archive (a, b) WITH old AS (SELECT id FROM t) SELECT a, b FROM live WHERE created_at < $2",
+			[]interface{}{1, "2020-01-01"},
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("a", "b").FromSelect(live().With("old", Select("id").From("t"))).
+				With("v", ValuesList().Row(1), "id"),
+			"INSERT INTO archive (a, b) WITH v (id) AS (VALUES ROW(?)), old AS (SELECT id FROM t) SELECT a, b FROM live WHERE created_at < ?",
+			[]interface{}{1, "2020-01-01"},
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("a", "b", "c").FromSelect(live()),
+			"",
+			nil,
+			ErrInsertSelectColumnsDiffLen,
+		},
+		{
+			Insert().Into("archive").Columns("a", "b").Values(1, 2).FromSelect(live()),
+			"",
+			nil,
+			ErrInsertValuesAndSelect,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
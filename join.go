@@ -0,0 +1,146 @@
+package sqlq
+
+import (
+	"errors"
+)
+
+var ErrJoinEmptyTable = errors.New("Table Name is required to do Join, use Join() function to specify Table Name")
+var ErrJoinEmptyCondition = errors.New("Condition is required to do Join, use On() or Using() to specify Join Condition")
+var ErrJoinCrossCondition = errors.New("Cross Join can't have Join Condition")
+var ErrJoinUsingMixed = errors.New("Using() can't be mixed with other Join Conditions")
+var ErrUsingOutsideJoin = errors.New("Using() can only be used as Join Condition")
+var ErrJoinFirst = errors.New("First Join must be Join() with On() conditions or CrossJoin() on this Dialect")
+var ErrSubqueryAlias = errors.New("Subquery used as Table must have an alias, use As() function to specify the alias")
+
+type join struct {
+	kind       string
+	table      interface{}
+	conditions []Condition
+}
+
+type usingColumns []string
+
+type aliased struct {
+	value interface{}
+	alias string
+}
+
+type Ident string
+
+// As gives an alias to a table or column.
+func As(value interface{}, alias string) interface{} {
+	return aliased{value, alias}
+}
+
+// Col references a column where a value is expected, so it is not bound as argument.
+func Col(name string) Ident {
+	return Ident(name)
+}
+
+// On compares two columns, it is usually used as Join Condition.
+func On(column string, operator string, otherColumn string) Condition {
+	return condition{column, operator, Col(otherColumn)}
+}
+
+// Using joins on columns with the same name in both tables.
+func Using(columns ...string) Condition {
+	return usingColumns(columns)
+}
+
+func (u usingColumns) writeCondition(w *sqlWriter) error {
+	return ErrUsingOutsideJoin
+}
+
+func (w *sqlWriter) writeTable(table interface{}) error {
+	switch t := table.(type) {
+	case string:
+		w.writeIdent(t)
+	case Expr:
+		return w.writeExpr(t)
+	case *SelectBuilder, *CompoundBuilder:
+		return ErrSubqueryAlias
+	case aliased:
+		switch sub := t.value.(type) {
+		case *SelectBuilder, *CompoundBuilder:
+			if err := w.writeSubquery(sub.(query)); err != nil {
+				return err
+			}
+		default:
+			if err := w.writeTable(t.value); err != nil {
+				return err
+			}
+		}
+		w.WriteString(" AS ")
+		w.writeAlias(t.alias)
+	default:
+		return ErrColumnUnsupported
+	}
+	return nil
+}
+
+func (w *sqlWriter) writeJoins(joins []join) error {
+	for _, j := range joins {
+		if j.table == nil || j.table == "" {
+			return ErrJoinEmptyTable
+		}
+		w.WriteString(" " + j.kind + " ")
+		if err := w.writeTable(j.table); err != nil {
+			return err
+		}
+
+		var using usingColumns
+		var on []Condition
+		for _, c := range j.conditions {
+			if u, ok := c.(usingColumns); ok {
+				using = append(using, u...)
+			} else if !isEmptyCondition(c) {
+				on = append(on, c)
+			}
+		}
+		switch {
+		case j.kind == "CROSS JOIN":
+			if len(using) > 0 || len(on) > 0 {
+				return ErrJoinCrossCondition
+			}
+		case len(using) > 0:
+			if len(on) > 0 {
+				return ErrJoinUsingMixed
+			}
+			w.WriteString(" USING (")
+			w.writeIdents(using)
+			w.WriteString(")")
+		case len(on) > 0:
+			w.WriteString(" ON ")
+			if err := unwrapCondition(And(on...)).writeCondition(w); err != nil {
+				return err
+			}
+		default:
+			return ErrJoinEmptyCondition
+		}
+	}
+	return nil
+}
+
+// writeFirstJoinTable writes the table of the first join on its own, for dialects that list it in FROM or USING
+// of an UPDATE or DELETE, and returns its ON conditions to put in WHERE.
+func (w *sqlWriter) writeFirstJoinTable(first join) ([]Condition, error) {
+	if first.kind != "INNER JOIN" && first.kind != "CROSS JOIN" {
+		return nil, ErrJoinFirst
+	}
+	var on []Condition
+	for _, c := range first.conditions {
+		if _, ok := c.(usingColumns); ok {
+			return nil, ErrJoinFirst
+		} else if !isEmptyCondition(c) {
+			on = append(on, c)
+		}
+	}
+	if first.table == nil || first.table == "" {
+		return nil, ErrJoinEmptyTable
+	} else if first.kind == "INNER JOIN" && len(on) <= 0 {
+		return nil, ErrJoinEmptyCondition
+	} else if first.kind == "CROSS JOIN" && len(on) > 0 {
+		return nil, ErrJoinCrossCondition
+	}
+	return on, w.writeTable(first.table)
+}
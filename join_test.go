@@ -0,0 +1,79 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSqlWriter_WriteJoins(t *testing.T) {
+	tables := []struct {
+		Joins  []join
+		Output string
+		Args   []interface{}
+		Error  error
+	}{
+		{
+			[]join{{"INNER JOIN", "orders", []Condition{On("orders.user_id", "=", "users.id")}}},
+			" INNER JOIN orders ON orders.user_id = users.id",
+			nil,
+			nil,
+		},
+		{
+			[]join{{"LEFT JOIN", As("orders", "o"), []Condition{On("o.user_id", "=", "u.id"), Cond("o.status", "=", "paid")}}},
+			" LEFT JOIN orders AS o ON o.user_id = u.id AND o.status = ?",
+			[]interface{}{"paid"},
+			nil,
+		},
+		{
+			[]join{{"RIGHT JOIN", "orders", []Condition{Using("user_id", "shop_id")}}},
+			" RIGHT JOIN orders USING (user_id, shop_id)",
+			nil,
+			nil,
+		},
+		{
+			[]join{{"CROSS JOIN", "sizes", nil}, {"FULL JOIN", "colors", []Condition{Or(On("a", "=", "b"), On("a", "=", "c"))}}},
+			" CROSS JOIN sizes FULL JOIN colors ON a = b OR a = c",
+			nil,
+			nil,
+		},
+		{
+			[]join{{"INNER JOIN", "", []Condition{On("a", "=", "b")}}},
+			"",
+			nil,
+			ErrJoinEmptyTable,
+		},
+		{
+			[]join{{"INNER JOIN", "orders", nil}},
+			" INNER JOIN orders",
+			nil,
+			ErrJoinEmptyCondition,
+		},
+		{
+			[]join{{"CROSS JOIN", "orders", []Condition{On("a", "=", "b")}}},
+			" CROSS JOIN orders",
+			nil,
+			ErrJoinCrossCondition,
+		},
+		{
+			[]join{{"INNER JOIN", "orders", []Condition{Using("id"), On("a", "=", "b")}}},
+			" INNER JOIN orders",
+			nil,
+			ErrJoinUsingMixed,
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(MySQL, false)
+		err := w.writeJoins(table.Joins)
+		if w.String() != table.Output || !reflect.DeepEqual(w.args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v got %v %v %v", table.Output, table.Args, table.Error, w.String(), w.args, err)
+		}
+	}
+}
+
+func TestUsing_OutsideJoin(t *testing.T) {
+	_, err := Select("id").From("users").WhereCond(Using("id")).Sql()
+	if !errors.Is(err, ErrUsingOutsideJoin) {
+		t.Errorf("Expected %v got %v", ErrUsingOutsideJoin, err)
+	}
+}
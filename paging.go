@@ -0,0 +1,77 @@
+package sqlq
+
+import (
+	"strconv"
+)
+
+type pagination struct {
+	limit   int
+	limited bool
+	offset  int
+	badPage bool
+}
+
+func (p *pagination) setLimit(limit int) {
+	p.limit = limit
+	p.limited = true
+}
+
+func (p *pagination) setNoLimit() {
+	p.limit = 0
+	p.limited = false
+}
+
+func (p *pagination) setPage(page int, size int) {
+	p.badPage = page < 1 || size < 0
+	p.setLimit(size)
+	p.offset = (page - 1) * size
+}
+
+func (p pagination) paged() bool {
+	return p.limited || p.offset > 0
+}
+
+func (p pagination) validate() error {
+	if p.badPage {
+		return ErrSelectPageInvalid
+	} else if p.limit < 0 {
+		return ErrSelectLimitNegative
+	} else if p.offset < 0 {
+		return ErrSelectOffsetNegative
+	}
+	return nil
+}
+
+func (pg pagination) check(p *problems) {
+	switch err := pg.validate(); err {
+	case nil:
+	case ErrSelectOffsetNegative:
+		p.add(&ValidationError{Clause: "OFFSET", Err: err})
+	default:
+		p.add(&ValidationError{Clause: "LIMIT", Err: err})
+	}
+}
+
+// write writes the paging that comes after ORDER BY, TOP and ROW_NUMBER() are written by the select itself.
+func (p pagination) write(w *sqlWriter) {
+	switch paging := w.dialect.PagingStyle(); paging {
+	case PagingLimitOffset:
+		if p.limited {
+			w.WriteString(" LIMIT " + strconv.Itoa(p.limit))
+		} else if p.offset > 0 && w.dialect.LimitAll() != "" {
+			w.WriteString(" LIMIT " + w.dialect.LimitAll())
+		}
+		if p.offset > 0 {
+			w.WriteString(" OFFSET " + strconv.Itoa(p.offset))
+		}
+	case PagingTop, PagingFetchFirst:
+		if p.offset > 0 {
+			w.WriteString(" OFFSET " + strconv.Itoa(p.offset) + " ROWS")
+			if p.limited {
+				w.WriteString(" FETCH NEXT " + strconv.Itoa(p.limit) + " ROWS ONLY")
+			}
+		} else if p.limited && paging == PagingFetchFirst {
+			w.WriteString(" FETCH FIRST " + strconv.Itoa(p.limit) + " ROWS ONLY")
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package sqlq
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPagination_Write(t *testing.T) {
+	tables := []struct {
+		Paging  pagination
+		Dialect Dialect
+		Output  string
+	}{
+		{pagination{}, MySQL, ""},
+		{pagination{limit: 0, limited: true}, MySQL, " LIMIT 0"},
+		{pagination{limit: 5, limited: true, offset: 10}, PostgreSQL, " LIMIT 5 OFFSET 10"},
+		{pagination{offset: 10}, SQLite, " LIMIT -1 OFFSET 10"},
+		{pagination{limit: 5, limited: true}, SQLServer, ""},
+		{pagination{limit: 5, limited: true, offset: 10}, SQLServer, " OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY"},
+		{pagination{limit: 5, limited: true}, fetchFirstDialect{}, " FETCH FIRST 5 ROWS ONLY"},
+		{pagination{limit: 5, limited: true, offset: 10}, SQLServer2008, ""},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(table.Dialect, false)
+		table.Paging.write(w)
+		if w.String() != table.Output {
+			t.Errorf("%v: Expected %v got %v", table.Dialect.Name(), table.Output, w.String())
+		}
+	}
+}
+
+func TestPagination_Validate(t *testing.T) {
+	tables := []struct {
+		Paging pagination
+		Error  error
+	}{
+		{pagination{}, nil},
+		{pagination{limit: -1, limited: true}, ErrSelectLimitNegative},
+		{pagination{offset: -1}, ErrSelectOffsetNegative},
+		{pagination{badPage: true}, ErrSelectPageInvalid},
+	}
+	for _, table := range tables {
+		err := table.Paging.validate()
+		if !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v got %v", table.Error, err)
+		}
+	}
+}
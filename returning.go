@@ -0,0 +1,61 @@
+package sqlq
+
+import (
+	"errors"
+)
+
+var ErrReturningUnsupported = errors.New("Dialect doesn't support Returning")
+
+func (ib *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	ib.returning = append(ib.returning, columns...)
+	return ib
+}
+
+func (ub *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	ub.returning = append(ub.returning, columns...)
+	return ub
+}
+
+func (db *DeleteBuilder) Returning(columns ...string) *DeleteBuilder {
+	db.returning = append(db.returning, columns...)
+	return db
+}
+
+// writeReturning writes RETURNING at the end of the statement, dialects with OUTPUT write it with writeOutput instead.
+func (w *sqlWriter) writeReturning(columns []string) error {
+	if len(columns) <= 0 {
+		return nil
+	}
+	switch {
+	case w.dialect.Supports(FeatureReturning):
+		w.WriteString(" RETURNING ")
+		for i, column := range columns {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			if err := w.writeColumn(column); err != nil {
+				return err
+			}
+		}
+	case !w.dialect.Supports(FeatureOutput):
+		return ErrReturningUnsupported
+	}
+	return nil
+}
+
+// writeOutput writes OUTPUT with the columns of the INSERTED or DELETED rows for dialects that support it.
+func (w *sqlWriter) writeOutput(columns []string, rows string) error {
+	if len(columns) <= 0 || !w.dialect.Supports(FeatureOutput) {
+		return nil
+	}
+	w.WriteString(" OUTPUT ")
+	for i, column := range columns {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		if err := w.writeColumn(rows + "." + column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
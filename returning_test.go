@@ -0,0 +1,98 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReturning(t *testing.T) {
+	tables := []struct {
+		Builder interface {
+			ToSql() (string, []interface{}, error)
+		}
+		Output string
+		Args   []interface{}
+		Error  error
+	}{
+		{
+			Insert().Into("users").Columns("name").Values("a").Returning("id", "created_at").Dialect(PostgreSQL),
+			"INSERT INTO users (name) VALUES ($1) RETURNING id, created_at",
+			[]interface{}{"a"},
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("name").Values("a").Returning("id").Dialect(SQLServer),
+			"INSERT INTO users (name) OUTPUT INSERTED.id VALUES (@p1)",
+			[]interface{}{"a"},
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("name").FromSelect(Select("name").From("staff")).Returning("id").Dialect(SQLServer),
+			"INSERT INTO users (name) OUTPUT INSERTED.id SELECT name FROM staff",
+			nil,
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("email").Values("a").OnConflict("email").DoNothing().Returning("id").Dialect(SQLite),
+			"INSERT INTO users (email) VALUES (?) ON CONFLICT (email) DO NOTHING RETURNING id",
+			[]interface{}{"a"},
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("email").Values("a").OnConflict("email").DoNothing().Returning("id").Dialect(SQLServer),
+			"MERGE INTO users USING (VALUES (@p1)) AS sqlq_source (email) ON users.email = sqlq_source.email " +
+				"WHEN NOT MATCHED THEN INSERT (email) VALUES (sqlq_source.email) OUTPUT INSERTED.id;",
+			[]interface{}{"a"},
+			nil,
+		},
+		{
+			Update("users").Set("name", "a").Where("id", "=", 1).Returning("id", "name").Dialect(SQLite),
+			"UPDATE users SET name = ? WHERE id = ? RETURNING id, name",
+			[]interface{}{"a", 1},
+			nil,
+		},
+		{
+			Update("users").Set("name", "a").Where("id", "=", 1).Returning("name").Dialect(SQLServer),
+			"UPDATE users SET name = @p1 OUTPUT INSERTED.name WHERE id = @p2",
+			[]interface{}{"a", 1},
+			nil,
+		},
+		{
+			Delete().From("users").Where("id", "=", 1).Returning("*").Dialect(PostgreSQL),
+			"DELETE FROM users WHERE id = $1 RETURNING *",
+			[]interface{}{1},
+			nil,
+		},
+		{
+			Delete().From("users").Where("id", "=", 1).Returning("id", "name").Dialect(SQLServer),
+			"DELETE FROM users OUTPUT DELETED.id, DELETED.name WHERE id = @p1",
+			[]interface{}{1},
+			nil,
+		},
+		{
+			Insert().Into("users").Columns("name").Values("a").Returning("id"),
+			"",
+			nil,
+			ErrReturningUnsupported,
+		},
+		{
+			Update("users").Set("name", "a").AllRows().Returning("id").Dialect(MySQL),
+			"",
+			nil,
+			ErrReturningUnsupported,
+		},
+		{
+			Delete().From("users").AllRows().Returning("id").Dialect(MySQL),
+			"",
+			nil,
+			ErrReturningUnsupported,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
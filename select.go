@@ -3,98 +3,293 @@ package sqlq
 import (
 	"errors"
 	"strconv"
-	"strings"
 )
 
-var errSelectEmptyTable = errors.New("\nTable Name is required to do Select Operation\nUse From() function to specify Table Name")
-var errSelectEmptyColumns = errors.New("\nColumns is required to do Select Operation\nUse Select() or Columns() function to specify Columns")
+var ErrSelectEmptyTable = errors.New("Table Name is required to do Select Operation, use From() function to specify Table Name")
+var ErrSelectEmptyColumns = errors.New("Columns is required to do Select Operation, use Select() or Columns() function to specify Columns")
 
-var errSelectLimitNegative = errors.New("\nLimit can't be negative value")
-var errSelectColumnsSame = errors.New("You have same Columns in your query")
+var ErrSelectLimitNegative = errors.New("Limit can't be negative value")
+var ErrSelectOffsetNegative = errors.New("Offset can't be negative value")
+var ErrSelectPageInvalid = errors.New("Page starts from 1 and Page Size can't be negative value")
+var ErrSelectColumnsSame = errors.New("You have same Columns in your query")
+var ErrSelectHavingWithoutGroup = errors.New("Having requires Group By or aggregate Columns, use GroupBy() function to specify Group By Columns")
 
 type SelectBuilder struct {
-	table         string
-	columns       []string
-	conditionsAnd []string
-	conditionsOr  []string
-	order         []string
-	limit         int
+	with    withClause
+	table   interface{}
+	joins   []join
+	columns []interface{}
+	where   Condition
+	groupBy []interface{}
+	having  Condition
+	windows []namedWindow
+	order   []orderBy
+	paging  pagination
+	check   validation
+	dialect Dialect
 }
 
-func (sb *SelectBuilder) From(table string) *SelectBuilder {
+func (sb *SelectBuilder) From(table interface{}) *SelectBuilder {
 	sb.table = table
 	return sb
 }
 
-func (sb *SelectBuilder) Columns(columns ...string) *SelectBuilder {
+func (sb *SelectBuilder) Join(table interface{}, on ...Condition) *SelectBuilder {
+	sb.joins = append(sb.joins, join{"INNER JOIN", table, on})
+	return sb
+}
+
+func (sb *SelectBuilder) LeftJoin(table interface{}, on ...Condition) *SelectBuilder {
+	sb.joins = append(sb.joins, join{"LEFT JOIN", table, on})
+	return sb
+}
+
+func (sb *SelectBuilder) RightJoin(table interface{}, on ...Condition) *SelectBuilder {
+	sb.joins = append(sb.joins, join{"RIGHT JOIN", table, on})
+	return sb
+}
+
+func (sb *SelectBuilder) FullJoin(table interface{}, on ...Condition) *SelectBuilder {
+	sb.joins = append(sb.joins, join{"FULL JOIN", table, on})
+	return sb
+}
+
+func (sb *SelectBuilder) CrossJoin(table interface{}) *SelectBuilder {
+	sb.joins = append(sb.joins, join{"CROSS JOIN", table, nil})
+	return sb
+}
+
+func (sb *SelectBuilder) Columns(columns ...interface{}) *SelectBuilder {
 	sb.columns = append(sb.columns, columns...)
 	return sb
 }
 
-func (sb *SelectBuilder) Where(column string, operator string, value string) *SelectBuilder {
-	if column != "" && operator != "" && value != "" {
-		sb.conditionsAnd = append(sb.conditionsAnd, column+" "+operator+" '"+value+"'")
+func (sb *SelectBuilder) Where(column interface{}, operator string, value interface{}) *SelectBuilder {
+	if sb.check.allowCondition("WHERE", column, operator, value) {
+		sb.where = joinConditions("AND", sb.where, condition{column, operator, value})
 	}
 	return sb
 }
 
-func (sb *SelectBuilder) WhereOr(column string, operator string, value string) *SelectBuilder {
-	if column != "" && operator != "" && value != "" {
-		sb.conditionsOr = append(sb.conditionsOr, column+" "+operator+" '"+value+"'")
+func (sb *SelectBuilder) WhereOr(column interface{}, operator string, value interface{}) *SelectBuilder {
+	if sb.check.allowCondition("WHERE", column, operator, value) {
+		sb.where = joinConditions("OR", sb.where, condition{column, operator, value})
 	}
 	return sb
 }
 
-func (sb *SelectBuilder) OrderBy(column string, order string) *SelectBuilder {
-	sb.order = append(sb.order, column+" "+order)
+func (sb *SelectBuilder) WhereCond(conditions ...Condition) *SelectBuilder {
+	sb.where = joinConditions("AND", sb.where, And(conditions...))
+	return sb
+}
+
+func (sb *SelectBuilder) WhereOrCond(conditions ...Condition) *SelectBuilder {
+	sb.where = joinConditions("OR", sb.where, And(conditions...))
+	return sb
+}
+
+func (sb *SelectBuilder) GroupBy(columns ...interface{}) *SelectBuilder {
+	sb.groupBy = append(sb.groupBy, columns...)
+	return sb
+}
+
+func (sb *SelectBuilder) Having(column interface{}, operator string, value interface{}) *SelectBuilder {
+	if sb.check.allowCondition("HAVING", column, operator, value) {
+		sb.having = joinConditions("AND", sb.having, condition{column, operator, value})
+	}
+	return sb
+}
+
+func (sb *SelectBuilder) HavingOr(column interface{}, operator string, value interface{}) *SelectBuilder {
+	if sb.check.allowCondition("HAVING", column, operator, value) {
+		sb.having = joinConditions("OR", sb.having, condition{column, operator, value})
+	}
+	return sb
+}
+
+func (sb *SelectBuilder) HavingCond(conditions ...Condition) *SelectBuilder {
+	sb.having = joinConditions("AND", sb.having, And(conditions...))
+	return sb
+}
+
+func (sb *SelectBuilder) HavingOrCond(conditions ...Condition) *SelectBuilder {
+	sb.having = joinConditions("OR", sb.having, And(conditions...))
+	return sb
+}
+
+func (sb *SelectBuilder) Window(name string, window *WindowBuilder) *SelectBuilder {
+	sb.windows = append(sb.windows, namedWindow{name, window})
+	return sb
+}
+
+func (sb *SelectBuilder) OrderBy(column interface{}, order string) *SelectBuilder {
+	sb.order = append(sb.order, orderBy{column, order})
 	return sb
 }
 
 func (sb *SelectBuilder) Limit(limit int) *SelectBuilder {
-	sb.limit = limit
+	sb.paging.setLimit(limit)
 	return sb
 }
 
-func (sb *SelectBuilder) Sql() (string, error) {
-	var err error
+func (sb *SelectBuilder) NoLimit() *SelectBuilder {
+	sb.paging.setNoLimit()
+	return sb
+}
 
-	if sb.table == "" {
-		err = errSelectEmptyTable
-		return "", err
-	} else if len(sb.columns) <= 0 {
-		err = errSelectEmptyColumns
-		return "", err
-	} else if sb.limit < 0 {
-		err = errSelectLimitNegative
-		return "", err
-	} else if checkSameColumns(sb.columns) {
-		err = errSelectColumnsSame
+func (sb *SelectBuilder) Offset(offset int) *SelectBuilder {
+	sb.paging.offset = offset
+	return sb
+}
+
+// Page limits the select to the given page, pages start from 1.
+func (sb *SelectBuilder) Page(page int, size int) *SelectBuilder {
+	sb.paging.setPage(page, size)
+	return sb
+}
+
+func (sb *SelectBuilder) With(name string, query interface{}, columns ...string) *SelectBuilder {
+	sb.with.add(false, name, query, columns)
+	return sb
+}
+
+// WithRecursive adds a common table expression that can reference itself, it makes the whole WITH recursive.
+func (sb *SelectBuilder) WithRecursive(name string, query interface{}, columns ...string) *SelectBuilder {
+	sb.with.add(true, name, query, columns)
+	return sb
+}
+
+func (sb *SelectBuilder) Dialect(dialect Dialect) *SelectBuilder {
+	sb.dialect = dialect
+	return sb
+}
+
+func (sb *SelectBuilder) Sql() (string, error) {
+	w := newSqlWriter(sb.dialect, true)
+	if err := sb.write(w); err != nil {
 		return "", err
 	}
+	return w.String(), nil
+}
 
-	sql := "SELECT " + strings.Join(sb.columns, ", ") + " FROM " + sb.table
-	if len(sb.conditionsAnd) > 0 || len(sb.conditionsOr) > 0 {
-		sql += " WHERE "
+func (sb *SelectBuilder) ToSql() (string, []interface{}, error) {
+	w := newSqlWriter(sb.dialect, false)
+	if err := sb.write(w); err != nil {
+		return "", nil, err
 	}
-	if len(sb.conditionsAnd) > 0 {
-		sql += strings.Join(sb.conditionsAnd, " AND ")
+	return w.String(), w.args, nil
+}
+
+func (sb *SelectBuilder) write(w *sqlWriter) (err error) {
+	defer w.enter("SELECT", &err)()
+	if err := sb.validate(); err != nil {
+		return err
+	}
+
+	w.clause = "WITH"
+	if err := w.writeWith(sb.with); err != nil {
+		return err
 	}
-	if len(sb.conditionsOr) > 0 {
-		if len(sb.conditionsAnd) > 0 {
-			sql += " OR"
+	paging := w.dialect.PagingStyle()
+	rowNumber := paging == PagingRowNumber && sb.paging.offset > 0
+	if rowNumber {
+		w.WriteString("SELECT * FROM (")
+	}
+	w.WriteString("SELECT ")
+	if sb.paging.limited && sb.paging.offset == 0 && (paging == PagingTop || paging == PagingRowNumber) {
+		w.WriteString("TOP " + strconv.Itoa(sb.paging.limit) + " ")
+	}
+	w.clause = "COLUMNS"
+	if err := w.writeColumns(sb.columns); err != nil {
+		return err
+	}
+	if rowNumber {
+		w.clause = "ORDER BY"
+		w.WriteString(", ROW_NUMBER() OVER (")
+		if err := sb.writeOrderBy(w); err != nil {
+			return err
+		}
+		w.WriteString(") AS sqlq_rn")
+	}
+	w.clause = "FROM"
+	w.WriteString(" FROM ")
+	if err := w.writeTable(sb.table); err != nil {
+		return err
+	}
+	w.clause = "JOIN"
+	if err := w.writeJoins(sb.joins); err != nil {
+		return err
+	}
+	w.clause = "WHERE"
+	if err := w.writeWhere(sb.where); err != nil {
+		return err
+	}
+	w.clause = "GROUP BY"
+	if len(sb.groupBy) > 0 {
+		w.WriteString(" GROUP BY ")
+		if err := w.writeColumns(sb.groupBy); err != nil {
+			return err
+		}
+	}
+	w.clause = "HAVING"
+	if !isEmptyCondition(sb.having) {
+		w.WriteString(" HAVING ")
+		if err := unwrapCondition(sb.having).writeCondition(w); err != nil {
+			return err
+		}
+	}
+	w.clause = "WINDOW"
+	if err := w.writeNamedWindows(sb.windows); err != nil {
+		return err
+	}
+	if rowNumber {
+		w.WriteString(") AS sqlq_paged WHERE sqlq_rn > " + strconv.Itoa(sb.paging.offset))
+		if sb.paging.limited {
+			w.WriteString(" AND sqlq_rn <= " + strconv.Itoa(sb.paging.offset+sb.paging.limit))
+		}
+		w.WriteString(" ORDER BY sqlq_rn")
+		return nil
+	}
+	w.clause = "ORDER BY"
+	if len(sb.order) > 0 || (paging == PagingTop && sb.paging.offset > 0) {
+		w.WriteString(" ")
+		if err := sb.writeOrderBy(w); err != nil {
+			return err
 		}
-		sql += " " + strings.Join(sb.conditionsOr, " OR ")
 	}
-	if len(sb.order) > 0 {
-		sql += " ORDER BY " + strings.Join(sb.order, ", ")
+	sb.paging.write(w)
+	return nil
+}
+
+func (sb *SelectBuilder) validate() error {
+	p := problems{statement: "SELECT"}
+	p.addAll(sb.check.errs)
+	if sb.table == nil || sb.table == "" {
+		p.add(&ValidationError{Clause: "FROM", Err: ErrSelectEmptyTable})
 	}
-	if sb.limit > 0 {
-		sql += " LIMIT " + strconv.Itoa(sb.limit)
+	if len(sb.columns) <= 0 {
+		p.add(&ValidationError{Clause: "COLUMNS", Err: ErrSelectEmptyColumns})
+	}
+	sb.paging.check(&p)
+	if duplicates := duplicateColumns(stringColumns(sb.columns)); len(duplicates) > 0 {
+		p.add(&ValidationError{Clause: "COLUMNS", Columns: duplicates, Err: ErrSelectColumnsSame})
+	}
+	if !isEmptyCondition(sb.having) && len(sb.groupBy) <= 0 && !hasAggregate(sb.columns) {
+		p.add(&ValidationError{Clause: "HAVING", Err: ErrSelectHavingWithoutGroup})
+	}
+	return p.err()
+}
+
+// writeOrderBy falls back to ORDER BY (SELECT NULL) for dialects that require an order to page rows.
+func (sb *SelectBuilder) writeOrderBy(w *sqlWriter) error {
+	if len(sb.order) <= 0 {
+		w.WriteString("ORDER BY (SELECT NULL)")
+		return nil
 	}
-	return sql, err
+	return w.writeOrderBy(sb.order)
 }
 
-func Select(columns ...string) *SelectBuilder {
+func Select(columns ...interface{}) *SelectBuilder {
 	sb := &SelectBuilder{}
 	sb.columns = append(sb.columns, columns...)
 	return sb
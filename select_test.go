@@ -1,22 +1,23 @@
 package sqlq
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
 
 func TestSelect(t *testing.T) {
 	tables := []struct {
-		Columns []string
+		Columns []interface{}
 		Output  *SelectBuilder
 	}{
 		{
-			[]string{},
-			Select([]string{}...),
+			[]interface{}{},
+			Select([]interface{}{}...),
 		},
 		{
-			[]string{"id", "name", "email"},
-			Select([]string{"id", "name", "email"}...),
+			[]interface{}{"id", "name", "email"},
+			Select([]interface{}{"id", "name", "email"}...),
 		},
 	}
 	for _, table := range tables {
@@ -51,16 +52,16 @@ func TestSelectBuilder_From(t *testing.T) {
 
 func TestSelectBuilder_Columns(t *testing.T) {
 	tables := []struct {
-		Columns []string
+		Columns []interface{}
 		Output  *SelectBuilder
 	}{
 		{
-			[]string{},
-			Select().Columns([]string{}...),
+			[]interface{}{},
+			Select().Columns([]interface{}{}...),
 		},
 		{
-			[]string{"id", "name", "email"},
-			Select().Columns([]string{"id", "name", "email"}...),
+			[]interface{}{"id", "name", "email"},
+			Select().Columns([]interface{}{"id", "name", "email"}...),
 		},
 	}
 	for _, table := range tables {
@@ -334,7 +335,7 @@ func TestSelectBuilder_Sql(t *testing.T) {
 			[]string{},
 			0,
 			"",
-			errSelectEmptyTable,
+			ErrSelectEmptyTable,
 		},
 		{
 			"users",
@@ -349,7 +350,7 @@ func TestSelectBuilder_Sql(t *testing.T) {
 			[]string{},
 			0,
 			"",
-			errSelectEmptyColumns,
+			ErrSelectEmptyColumns,
 		},
 		{
 			"users",
@@ -364,7 +365,7 @@ func TestSelectBuilder_Sql(t *testing.T) {
 			[]string{},
 			-1,
 			"",
-			errSelectLimitNegative,
+			ErrSelectLimitNegative,
 		},
 		{
 			"users",
@@ -379,7 +380,7 @@ func TestSelectBuilder_Sql(t *testing.T) {
 			[]string{},
 			0,
 			"",
-			errSelectColumnsSame,
+			ErrSelectColumnsSame,
 		},
 	}
 	for _, table := range tables {
@@ -396,11 +397,393 @@ func TestSelectBuilder_Sql(t *testing.T) {
 		for i := 0; i < len(table.OrderColumns) && i < len(table.OrderOrders); i++ {
 			slct.OrderBy(table.OrderColumns[i], table.OrderOrders[i])
 		}
-		slct.Limit(table.Limit)
+		if table.Limit != 0 {
+			slct.Limit(table.Limit)
+		}
 		result, err := slct.Sql()
-		if result != table.Output && err != table.Error {
+		if result != table.Output && !errors.Is(err, table.Error) {
 			t.Errorf("Expected %v\nGot %v\n", table.Output, result)
 			t.Errorf("Expected error %v\nGot %v\n", table.Error, err)
 		}
 	}
 }
+
+func TestSelectBuilder_ToSql(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Select("id", "name").From("users").Where("id", ">", "1").WhereOr("name", "LIKE", "O'Brien%").
+				OrderBy("name", "ASC").Limit(5),
+			"SELECT id, name FROM users WHERE id > ? OR name LIKE ? ORDER BY name ASC LIMIT 5",
+			[]interface{}{"1", "O'Brien%"},
+			nil,
+		},
+		{
+			Select("id").From("users"),
+			"SELECT id FROM users",
+			nil,
+			nil,
+		},
+		{
+			Select("id"),
+			"",
+			nil,
+			ErrSelectEmptyTable,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestSelectBuilder_SqlEscape(t *testing.T) {
+	result, err := Select("id").From("users").Where("name", "=", "O'Brien").Sql()
+	if result != "SELECT id FROM users WHERE name = 'O''Brien'" || err != nil {
+		t.Errorf("Expected escaped literal got %v %v", result, err)
+	}
+}
+
+func TestSelectBuilder_SqlRaw(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Select("id", Raw("COUNT(*) AS total")).From("users").Where("expires_at", "<", Raw("CURRENT_DATE")).
+				OrderBy(Raw("LOWER(name)"), "ASC"),
+			"SELECT id, COUNT(*) AS total FROM users WHERE expires_at < CURRENT_DATE ORDER BY LOWER(name) ASC",
+			nil,
+			nil,
+		},
+		{
+			Select("id").From("users").Where(Raw("LOWER(email)"), "=", Raw("LOWER(?)", "SQLQ@valuppo.com")),
+			"SELECT id FROM users WHERE LOWER(email) = LOWER(?)",
+			[]interface{}{"SQLQ@valuppo.com"},
+			nil,
+		},
+		{
+			Select("id", 5).From("users"),
+			"",
+			nil,
+			ErrColumnUnsupported,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestSelectBuilder_WhereCond(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Output  string
+		Args    []interface{}
+	}{
+		{
+			Select("id").From("posts").WhereCond(Or(Cond("author_id", "=", 1), Cond("public", "=", true))).
+				Where("tenant_id", "=", 7),
+			"SELECT id FROM posts WHERE (author_id = ? OR public = ?) AND tenant_id = ?",
+			[]interface{}{1, true, 7},
+		},
+		{
+			Select("id").From("posts").Where("a", "=", 1).WhereOr("b", "=", 2).Where("c", "=", 3),
+			"SELECT id FROM posts WHERE (a = ? OR b = ?) AND c = ?",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			Select("id").From("posts").Where("a", "=", 1).WhereOrCond(Cond("b", "=", 2), Not(Cond("c", "=", 3))),
+			"SELECT id FROM posts WHERE a = ? OR (b = ? AND NOT (c = ?))",
+			[]interface{}{1, 2, 3},
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || err != nil {
+			t.Errorf("Expected %v %v\nGot %v %v %v\n", table.Output, table.Args, result, args, err)
+		}
+	}
+}
+
+func TestSelectBuilder_Join(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Select("u.id", "o.total").From(As("users", "u")).Join(As("orders", "o"), On("o.user_id", "=", "u.id")).
+				Where("o.total", ">", 100),
+			"SELECT u.id, o.total FROM users AS u INNER JOIN orders AS o ON o.user_id = u.id WHERE o.total > ?",
+			[]interface{}{100},
+			nil,
+		},
+		{
+			Select("id").From("users").LeftJoin("profiles", Using("id")).RightJoin("teams", On("teams.id", "=", "users.team_id")).
+				FullJoin("roles", On("roles.id", "=", "users.role_id"), Cond("roles.active", "=", true)).CrossJoin("sizes"),
+			"SELECT id FROM users LEFT JOIN profiles USING (id) RIGHT JOIN teams ON teams.id = users.team_id " +
+				"FULL JOIN roles ON roles.id = users.role_id AND roles.active = ? CROSS JOIN sizes",
+			[]interface{}{true},
+			nil,
+		},
+		{
+			Select("id").From("users").Join("orders"),
+			"",
+			nil,
+			ErrJoinEmptyCondition,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestSelectBuilder_GroupBy(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Select("user_id", As(Raw("COUNT(*)"), "orders")).From("orders").Where("status", "=", "paid").
+				GroupBy("user_id").Having(Raw("COUNT(*)"), ">", 5).HavingOr(Raw("SUM(total)"), ">=", 1000).
+				OrderBy("orders", "DESC"),
+			"SELECT user_id, COUNT(*) AS orders FROM orders WHERE status = ? GROUP BY user_id " +
+				"HAVING COUNT(*) > ? OR SUM(total) >= ? ORDER BY orders DESC",
+			[]interface{}{"paid", 5, 1000},
+			nil,
+		},
+		{
+			Select("shop_id", "user_id").From("orders").GroupBy("shop_id", Raw("user_id")).
+				HavingCond(Or(Cond(Raw("MIN(total)"), ">", 1), Cond(Raw("MAX(total)"), "<", 9))),
+			"SELECT shop_id, user_id FROM orders GROUP BY shop_id, user_id HAVING MIN(total) > ? OR MAX(total) < ?",
+			[]interface{}{1, 9},
+			nil,
+		},
+		{
+			Select(Raw("COUNT(*)")).From("orders").Having(Raw("COUNT(*)"), ">", 5),
+			"SELECT COUNT(*) FROM orders HAVING COUNT(*) > ?",
+			[]interface{}{5},
+			nil,
+		},
+		{
+			Select("id").From("orders").Having(Raw("COUNT(*)"), ">", 5),
+			"",
+			nil,
+			ErrSelectHavingWithoutGroup,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestSelectBuilder_Offset(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Dialect Dialect
+		Output  string
+		Error   error
+	}{
+		{Select("id").From("users").Limit(10).Offset(20), MySQL, "SELECT id FROM users LIMIT 10 OFFSET 20", nil},
+		{Select("id").From("users").Limit(0), MySQL, "SELECT id FROM users LIMIT 0", nil},
+		{Select("id").From("users").Limit(5).NoLimit(), MySQL, "SELECT id FROM users", nil},
+		{Select("id").From("users").Offset(20), MySQL, "SELECT id FROM users LIMIT 18446744073709551615 OFFSET 20", nil},
+		{Select("id").From("users").Offset(20), SQLite, "SELECT id FROM users LIMIT -1 OFFSET 20", nil},
+		{Select("id").From("users").Offset(20), PostgreSQL, "SELECT id FROM users LIMIT ALL OFFSET 20", nil},
+		{Select("id").From("users").Page(3, 10), PostgreSQL, "SELECT id FROM users LIMIT 10 OFFSET 20", nil},
+		{Select("id").From("users").Page(1, 10), SQLServer, "SELECT TOP 10 id FROM users", nil},
+		{
+			Select("id").From("users").OrderBy("id", "ASC").Page(3, 10),
+			SQLServer,
+			"SELECT id FROM users ORDER BY id ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+			nil,
+		},
+		{
+			Select("id").From("users").Offset(20),
+			SQLServer,
+			"SELECT id FROM users ORDER BY (SELECT NULL) OFFSET 20 ROWS",
+			nil,
+		},
+		{
+			Select("id").From("users").Page(3, 10),
+			fetchFirstDialect{},
+			"SELECT id FROM users OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+			nil,
+		},
+		{Select("id").From("users").Limit(10), fetchFirstDialect{}, "SELECT id FROM users FETCH FIRST 10 ROWS ONLY", nil},
+		{Select("id").From("users").Limit(10), SQLServer2008, "SELECT TOP 10 id FROM users", nil},
+		{
+			Select("id", "name").From("users").Where("active", "=", true).OrderBy("name", "ASC").Page(3, 10),
+			SQLServer2008,
+			"SELECT * FROM (SELECT id, name, ROW_NUMBER() OVER (ORDER BY name ASC) AS sqlq_rn FROM users WHERE active = 1) " +
+				"AS sqlq_paged WHERE sqlq_rn > 20 AND sqlq_rn <= 30 ORDER BY sqlq_rn",
+			nil,
+		},
+		{
+			Select("id").From("users").Offset(5),
+			SQLServer2008,
+			"SELECT * FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY (SELECT NULL)) AS sqlq_rn FROM users) " +
+				"AS sqlq_paged WHERE sqlq_rn > 5 ORDER BY sqlq_rn",
+			nil,
+		},
+		{Select("id").From("users").Offset(-1), MySQL, "", ErrSelectOffsetNegative},
+		{Select("id").From("users").Limit(-1), MySQL, "", ErrSelectLimitNegative},
+		{Select("id").From("users").Page(0, 10), MySQL, "", ErrSelectPageInvalid},
+		{Select("id").From("users").Page(1, -10), MySQL, "", ErrSelectPageInvalid},
+	}
+	for _, table := range tables {
+		result, err := table.Builder.Dialect(table.Dialect).Sql()
+		if result != table.Output || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v\nGot %v %v\n", table.Dialect.Name(), table.Output, table.Error, result, err)
+		}
+	}
+}
+
+func TestSelectBuilder_Subquery(t *testing.T) {
+	paid := Select("user_id").From("orders").Where("status", "=", "paid")
+	tables := []struct {
+		Builder *SelectBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Select("id").From("users").Where("active", "=", true).Where("id", "IN", paid).Where("age", ">", 18),
+			"SELECT id FROM users WHERE active = $1 AND id IN (SELECT user_id FROM orders WHERE status = $2) AND age > $3",
+			[]interface{}{true, "paid", 18},
+			nil,
+		},
+		{
+			Select("t.user_id", "t.total").
+				From(As(Select("user_id", As(Raw("SUM(total)"), "total")).From("orders").Where("status", "=", "paid").GroupBy("user_id"), "t")).
+				Where("t.total", ">", 100),
+			"SELECT t.user_id, t.total FROM (SELECT user_id, SUM(total) AS total FROM orders WHERE status = $1 GROUP BY user_id) AS t " +
+				"WHERE t.total > $2",
+			[]interface{}{"paid", 100},
+			nil,
+		},
+		{
+			Select("id", As(Select(Raw("COUNT(*)")).From("orders").Where("orders.user_id", "=", Col("users.id")).Where("status", "=", "paid"), "paid_orders")).
+				From("users").Where("id", "=", 5),
+			"SELECT id, (SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id AND status = $1) AS paid_orders FROM users WHERE id = $2",
+			[]interface{}{"paid", 5},
+			nil,
+		},
+		{
+			Select("id").From("users").LeftJoin(As(paid, "p"), On("p.user_id", "=", "users.id")).WhereOr("name", "=", "sqlq"),
+			"SELECT id FROM users LEFT JOIN (SELECT user_id FROM orders WHERE status = $1) AS p ON p.user_id = users.id WHERE name = $2",
+			[]interface{}{"paid", "sqlq"},
+			nil,
+		},
+		{
+			Select("id").From(paid),
+			"",
+			nil,
+			ErrSubqueryAlias,
+		},
+		{
+			Select("id").From("users").Where("id", "IN", Select("user_id")),
+			"",
+			nil,
+			ErrSelectEmptyTable,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.Dialect(PostgreSQL).ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestSelectBuilder_SubqueryNil(t *testing.T) {
+	var nilSelect *SelectBuilder
+	var nilCompound *CompoundBuilder
+	tables := []struct {
+		Builder interface {
+			ToSql() (string, []interface{}, error)
+		}
+		Error error
+	}{
+		{Select("id").From("users").WhereCond(Exists(nil)), ErrSubqueryNil},
+		{Select("id").From("users").WhereCond(NotExists(nilSelect)), ErrSubqueryNil},
+		{Select("id").From("users").Where("id", "IN", nilSelect), ErrSubqueryNil},
+		{Select("id").From("users").WhereCond(In("id", nilCompound)), ErrSubqueryNil},
+		{Select("id", As(nilSelect, "total")).From("users"), ErrSubqueryNil},
+		{Select("id").From(As(nilSelect, "t")), ErrSubqueryNil},
+		{Select("id").From("t").With("t", nilSelect), ErrSubqueryNil},
+		{Select("id").From("t").With("t", (*ValuesBuilder)(nil), "id"), ErrValuesEmpty},
+		{Union(Select("id").From("users"), nil), ErrSubqueryNil},
+		{Update("users").Set("total", nilSelect).AllRows(), ErrSubqueryNil},
+	}
+	for _, table := range tables {
+		result, _, err := table.Builder.ToSql()
+		if result != "" || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v\nGot %v %v\n", table.Error, result, err)
+		}
+	}
+}
+
+func TestSelectBuilder_WhereIn(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Output  string
+	}{
+		{
+			Select("id").From("users").Where("id", "IN", []int64{1, 2, 3}).Where("active", "=", true),
+			"SELECT id FROM users WHERE id IN (1, 2, 3) AND active = TRUE",
+		},
+		{
+			Select("id").From("users").Where("role", "NOT IN", []string{"bot"}),
+			"SELECT id FROM users WHERE role NOT IN ('bot')",
+		},
+		{
+			Select("id").From("users").Where("id", "IN", []int{}).WhereOr("role", "=", "admin"),
+			"SELECT id FROM users WHERE 1 = 0 OR role = 'admin'",
+		},
+		{
+			Select("id").From("users").Where("active", "=", true).Where("id", "NOT IN", []int{}),
+			"SELECT id FROM users WHERE active = TRUE AND 1 = 1",
+		},
+	}
+	for _, table := range tables {
+		result, err := table.Builder.Dialect(PostgreSQL).Sql()
+		if result != table.Output || err != nil {
+			t.Errorf("Expected %v\nGot %v %v\n", table.Output, result, err)
+		}
+	}
+}
+
+func TestSelectBuilder_With(t *testing.T) {
+	tree := UnionAll(
+		Select("id", "parent_id", "name").From("categories").Where("id", "=", 3),
+		Select("c.id", "c.parent_id", "c.name").From(As("categories", "c")).Join(As("tree", "t"), On("c.parent_id", "=", "t.id")),
+	)
+	result, args, err := Select("id", "name").From("tree").Where("name", "LIKE", "a%").
+		WithRecursive("tree", tree, "id", "parent_id", "name").Dialect(PostgreSQL).ToSql()
+	if result != "WITH RECURSIVE tree (id, parent_id, name) AS (SELECT id, parent_id, name FROM categories WHERE id = $1 "+
+		"UNION ALL SELECT c.id, c.parent_id, c.name FROM categories AS c INNER JOIN tree AS t ON c.parent_id = t.id) "+
+		"SELECT id, name FROM tree WHERE name LIKE $2" || !reflect.DeepEqual(args, []interface{}{3, "a%"}) || err != nil {
+		t.Errorf("Got %v %v %v", result, args, err)
+	}
+}
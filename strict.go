@@ -0,0 +1,74 @@
+package sqlq
+
+import (
+	"errors"
+)
+
+var ErrConditionEmptyColumn = errors.New("Column of a Condition can't be empty")
+var ErrConditionEmptyOperator = errors.New("Operator of a Condition can't be empty")
+var ErrSetEmptyColumn = errors.New("Column of Set can't be empty")
+
+// validation collects the mistakes made while building, strict builders return them from Sql() and ToSql()
+// while lenient ones keep the old behaviour of dropping calls with empty strings.
+// Builders are strict unless Strict(false) is called, before the calls it should apply to.
+type validation struct {
+	lenient bool
+	errs    []*ValidationError
+}
+
+func (v *validation) record(clause string, err error, columns ...string) {
+	v.errs = append(v.errs, &ValidationError{Clause: clause, Columns: columns, Err: err})
+}
+
+// allowCondition reports whether a condition should be added, an empty string is a value like any other
+// unless the builder is lenient.
+func (v *validation) allowCondition(clause string, column interface{}, operator string, value interface{}) bool {
+	if v.lenient {
+		return column != "" && operator != "" && value != ""
+	}
+	if column == nil || column == "" {
+		v.record(clause, ErrConditionEmptyColumn)
+		return false
+	} else if operator == "" {
+		v.record(clause, ErrConditionEmptyOperator, columnNames(column)...)
+		return false
+	} else if _, ok := normalizeOperator(operator); !ok {
+		v.record(clause, ErrConditionOperatorInvalid, columnNames(column)...)
+		return false
+	}
+	return true
+}
+
+// columnNames returns the column to report in a ValidationError, if it is a name.
+func columnNames(column interface{}) []string {
+	if c, ok := column.(string); ok {
+		return []string{c}
+	}
+	return nil
+}
+
+// Strict(false) makes the select drop Where() and Having() calls that have an empty column, operator or value
+// instead of returning an error.
+func (sb *SelectBuilder) Strict(strict bool) *SelectBuilder {
+	sb.check.lenient = !strict
+	return sb
+}
+
+// Strict(false) makes the insert drop DoUpdateWhere() calls that have an empty column, operator or value
+// instead of returning an error.
+func (ib *InsertBuilder) Strict(strict bool) *InsertBuilder {
+	ib.check.lenient = !strict
+	return ib
+}
+
+// Strict(false) makes the update drop Set() calls and SetMultiple() pairs that have an empty column or value,
+// and Where() calls that have an empty column, operator or value, instead of returning an error.
+func (ub *UpdateBuilder) Strict(strict bool) *UpdateBuilder {
+	ub.check.lenient = !strict
+	return ub
+}
+
+func (db *DeleteBuilder) Strict(strict bool) *DeleteBuilder {
+	db.check.lenient = !strict
+	return db
+}
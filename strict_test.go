@@ -0,0 +1,122 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	tables := []struct {
+		Builder interface {
+			ToSql() (string, []interface{}, error)
+		}
+		Output string
+		Args   []interface{}
+		Error  error
+	}{
+		{
+			Select("id").From("users").Where("name", "=", ""),
+			"SELECT id FROM users WHERE name = ?",
+			[]interface{}{""},
+			nil,
+		},
+		{
+			Select("id").From("users").Where("", "=", 1),
+			"",
+			nil,
+			ErrConditionEmptyColumn,
+		},
+		{
+			Select("id").From("users").GroupBy("id").Having(Raw("COUNT(*)"), "", 1),
+			"",
+			nil,
+			ErrConditionEmptyOperator,
+		},
+		{
+			Select("id").From("users").Where("id", "=>", 1),
+			"",
+			nil,
+			ErrConditionOperatorInvalid,
+		},
+		{
+			Delete().From("users").Where("id", "; DROP TABLE users; --", 1),
+			"",
+			nil,
+			ErrConditionOperatorInvalid,
+		},
+		{
+			Update("users").Set("", "x").Set("name", "").Where("id", "=", 1),
+			"",
+			nil,
+			ErrSetEmptyColumn,
+		},
+		{
+			Update("users").Set("name", "").Set("email", "a@b.c").Where("id", "=", 1),
+			"UPDATE users SET name = ?, email = ? WHERE id = ?",
+			[]interface{}{"", "a@b.c", 1},
+			nil,
+		},
+		{
+			Update("users").SetMultiple([]string{"name", "email"}, []string{"", "a@b.c"}).Where("id", "=", 1),
+			"UPDATE users SET name = ?, email = ? WHERE id = ?",
+			[]interface{}{"", "a@b.c", 1},
+			nil,
+		},
+		{
+			Update("users").SetMultiple([]string{"name", "email"}, []string{"a"}).Where("id", "=", 1),
+			"",
+			nil,
+			ErrUpdateColumnsValuesDiffLen,
+		},
+		{
+			Delete().From("users").WhereOr("", "=", 1).Where("id", "=", 2),
+			"",
+			nil,
+			ErrConditionEmptyColumn,
+		},
+		{
+			Insert().Into("users").Columns("email").Values("a").OnConflict("email").DoUpdateSet("email", Excluded("email")).
+				DoUpdateWhere("", "=", 1).Dialect(PostgreSQL),
+			"",
+			nil,
+			ErrConditionEmptyColumn,
+		},
+		{
+			Select("id").From("users").Strict(false).Where("name", "=", "").Where("", "=", 1).Where("id", "=", 2),
+			"SELECT id FROM users WHERE id = ?",
+			[]interface{}{2},
+			nil,
+		},
+		{
+			Update("users").Strict(false).Set("", "x").Set("name", "").Set("email", "a@b.c").Where("id", "=", 1),
+			"UPDATE users SET email = ? WHERE id = ?",
+			[]interface{}{"a@b.c", 1},
+			nil,
+		},
+		{
+			Update("users").Strict(false).SetMultiple([]string{"", "name", "email"}, []string{"x", "", "a@b.c"}).Where("id", "=", 1),
+			"UPDATE users SET email = ? WHERE id = ?",
+			[]interface{}{"a@b.c", 1},
+			nil,
+		},
+		{
+			Update("users").Strict(false).SetMultiple([]string{"name", "email"}, []string{"a"}).Where("id", "=", 1),
+			"",
+			nil,
+			ErrUpdateColumnsValuesDiffLen,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+
+	_, err := Update("users").Set("name", "a").WhereOr("id", "", 1).Where("active", "=", true).Sql()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Clause != "WHERE" || !reflect.DeepEqual(validationErr.Columns, []string{"id"}) {
+		t.Errorf("Expected error naming the WHERE column\nGot %v\n", err)
+	}
+}
@@ -2,101 +2,248 @@ package sqlq
 
 import (
 	"errors"
-	"strings"
 )
 
-var errUpdateEmptyTables = errors.New("\nTable Name is required to do Update Operation\nUse Update() function to specify Table Name")
-var errUpdateEmptyColumns = errors.New("\nColumns is required to do Update Operation\nUse Set() function to specify Columns")
-var errUpdateEmptyValues = errors.New("\nValues is required to do Update Operation\nUse Set() function to specify Values")
+var ErrUpdateEmptyTables = errors.New("Table Name is required to do Update Operation, use Update() function to specify Table Name")
+var ErrUpdateEmptyColumns = errors.New("Columns is required to do Update Operation, use Set() function to specify Columns")
+var ErrUpdateEmptyValues = errors.New("Values is required to do Update Operation, use Set() function to specify Values")
 
-var errUpdateColumnsValuesDiffLen = errors.New("\nLength of Columns and Values must be the same")
-var errUpdateColumnsSame = errors.New("You have same Columns in your query")
+var ErrUpdateColumnsValuesDiffLen = errors.New("Length of Columns and Values must be the same")
+var ErrUpdateColumnsSame = errors.New("You have same Columns in your query")
+var ErrUpdateWithoutWhere = errors.New("Update without Where changes every row, use Where() to specify rows or AllRows() to update every row")
+var ErrUpdateJoinUnsupported = errors.New("Dialect doesn't support Join in Update")
 
 type UpdateBuilder struct {
-	table         string
-	columns       []string
-	values        []string
-	conditionsAnd []string
-	conditionsOr  []string
+	with      withClause
+	table     string
+	joins     []join
+	columns   []string
+	values    []interface{}
+	where     Condition
+	allRows   bool
+	returning []string
+	check     validation
+	dialect   Dialect
 }
 
-func (ub *UpdateBuilder) Set(column string, value string) *UpdateBuilder {
-	if column != "" {
-		ub.columns = append(ub.columns, column)
-	}
-	if value != "" {
-		ub.values = append(ub.values, "'"+value+"'")
+func (ub *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
+	if !ub.check.lenient && column == "" {
+		ub.check.record("SET", ErrSetEmptyColumn)
+		return ub
+	} else if ub.check.lenient && (column == "" || value == "") {
+		// the column and its value are dropped together so no value ends up set to another column
+		return ub
 	}
+	ub.columns = append(ub.columns, column)
+	ub.values = append(ub.values, value)
 	return ub
 }
 
 func (ub *UpdateBuilder) SetMultiple(columns []string, values []string) *UpdateBuilder {
-	for _, column := range columns {
-		if column != "" {
-			ub.columns = append(ub.columns, column)
-		}
+	if len(columns) != len(values) {
+		ub.check.record("SET", ErrUpdateColumnsValuesDiffLen, columns...)
+		return ub
 	}
-	for _, value := range values {
-		if value != "" {
-			ub.values = append(ub.values, "'"+value+"'")
-		}
+	for i := range columns {
+		ub.Set(columns[i], values[i])
 	}
 	return ub
 }
 
-func (ub *UpdateBuilder) Where(column string, operator string, value string) *UpdateBuilder {
-	if column != "" && operator != "" && value != "" {
-		ub.conditionsAnd = append(ub.conditionsAnd, column+" "+operator+" '"+value+"'")
+func (ub *UpdateBuilder) Join(table interface{}, on ...Condition) *UpdateBuilder {
+	ub.joins = append(ub.joins, join{"INNER JOIN", table, on})
+	return ub
+}
+
+func (ub *UpdateBuilder) LeftJoin(table interface{}, on ...Condition) *UpdateBuilder {
+	ub.joins = append(ub.joins, join{"LEFT JOIN", table, on})
+	return ub
+}
+
+func (ub *UpdateBuilder) RightJoin(table interface{}, on ...Condition) *UpdateBuilder {
+	ub.joins = append(ub.joins, join{"RIGHT JOIN", table, on})
+	return ub
+}
+
+func (ub *UpdateBuilder) FullJoin(table interface{}, on ...Condition) *UpdateBuilder {
+	ub.joins = append(ub.joins, join{"FULL JOIN", table, on})
+	return ub
+}
+
+func (ub *UpdateBuilder) CrossJoin(table interface{}) *UpdateBuilder {
+	ub.joins = append(ub.joins, join{"CROSS JOIN", table, nil})
+	return ub
+}
+
+func (ub *UpdateBuilder) Where(column interface{}, operator string, value interface{}) *UpdateBuilder {
+	if ub.check.allowCondition("WHERE", column, operator, value) {
+		ub.where = joinConditions("AND", ub.where, condition{column, operator, value})
 	}
 	return ub
 }
 
-func (ub *UpdateBuilder) WhereOr(column string, operator string, value string) *UpdateBuilder {
-	if column != "" && operator != "" && value != "" {
-		ub.conditionsOr = append(ub.conditionsOr, column+" "+operator+" '"+value+"'")
+func (ub *UpdateBuilder) WhereOr(column interface{}, operator string, value interface{}) *UpdateBuilder {
+	if ub.check.allowCondition("WHERE", column, operator, value) {
+		ub.where = joinConditions("OR", ub.where, condition{column, operator, value})
 	}
 	return ub
 }
 
+func (ub *UpdateBuilder) WhereCond(conditions ...Condition) *UpdateBuilder {
+	ub.where = joinConditions("AND", ub.where, And(conditions...))
+	return ub
+}
+
+func (ub *UpdateBuilder) WhereOrCond(conditions ...Condition) *UpdateBuilder {
+	ub.where = joinConditions("OR", ub.where, And(conditions...))
+	return ub
+}
+
+// AllRows allows the update to run without Where, which changes every row of the table.
+func (ub *UpdateBuilder) AllRows() *UpdateBuilder {
+	ub.allRows = true
+	return ub
+}
+
+func (ub *UpdateBuilder) With(name string, query interface{}, columns ...string) *UpdateBuilder {
+	ub.with.add(false, name, query, columns)
+	return ub
+}
+
+// WithRecursive adds a common table expression that can reference itself, it makes the whole WITH recursive.
+func (ub *UpdateBuilder) WithRecursive(name string, query interface{}, columns ...string) *UpdateBuilder {
+	ub.with.add(true, name, query, columns)
+	return ub
+}
+
+func (ub *UpdateBuilder) Dialect(dialect Dialect) *UpdateBuilder {
+	ub.dialect = dialect
+	return ub
+}
+
 func (ub *UpdateBuilder) Sql() (string, error) {
-	var err error
+	w := newSqlWriter(ub.dialect, true)
+	if err := ub.write(w); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+func (ub *UpdateBuilder) ToSql() (string, []interface{}, error) {
+	w := newSqlWriter(ub.dialect, false)
+	if err := ub.write(w); err != nil {
+		return "", nil, err
+	}
+	return w.String(), w.args, nil
+}
+
+func (ub *UpdateBuilder) write(w *sqlWriter) (err error) {
+	defer w.enter("UPDATE", &err)()
+	if err := ub.validate(); err != nil {
+		return err
+	}
+
+	w.clause = "WITH"
+	if err := w.writeWith(ub.with); err != nil {
+		return err
+	}
+	w.clause = "TABLE"
+	w.WriteString("UPDATE ")
+	w.writeIdent(ub.table)
+	if len(ub.joins) <= 0 {
+		if err := ub.writeSet(w); err != nil {
+			return err
+		}
+		return ub.writeWhereReturning(w, ub.where)
+	}
+
+	switch {
+	case w.dialect.Supports(FeatureUpdateJoin):
+		w.clause = "JOIN"
+		if err := w.writeJoins(ub.joins); err != nil {
+			return err
+		}
+		if err := ub.writeSet(w); err != nil {
+			return err
+		}
+		return ub.writeWhereReturning(w, ub.where)
+	case w.dialect.Supports(FeatureUpdateFrom):
+		// the updated table can't be joined, so the first table goes in FROM and its join condition in WHERE
+		if err := ub.writeSet(w); err != nil {
+			return err
+		}
+		w.clause = "JOIN"
+		w.WriteString(" FROM ")
+		on, err := w.writeFirstJoinTable(ub.joins[0])
+		if err != nil {
+			return err
+		}
+		if err := w.writeJoins(ub.joins[1:]); err != nil {
+			return err
+		}
+		return ub.writeWhereReturning(w, joinConditions("AND", And(on...), ub.where))
+	case w.dialect.Supports(FeatureUpdateFromJoin):
+		if err := ub.writeSet(w); err != nil {
+			return err
+		}
+		w.clause = "JOIN"
+		w.WriteString(" FROM ")
+		w.writeIdent(ub.table)
+		if err := w.writeJoins(ub.joins); err != nil {
+			return err
+		}
+		return ub.writeWhereReturning(w, ub.where)
+	}
+	w.clause = "JOIN"
+	return ErrUpdateJoinUnsupported
+}
 
+func (ub *UpdateBuilder) validate() error {
+	p := problems{statement: "UPDATE"}
+	p.addAll(ub.check.errs)
 	if ub.table == "" {
-		err = errUpdateEmptyTables
-		return "", err
-	} else if len(ub.columns) <= 0 {
-		err = errUpdateEmptyColumns
-		return "", err
+		p.add(&ValidationError{Clause: "TABLE", Err: ErrUpdateEmptyTables})
+	}
+	if len(ub.columns) <= 0 {
+		p.add(&ValidationError{Clause: "SET", Err: ErrUpdateEmptyColumns})
 	} else if len(ub.values) <= 0 {
-		err = errUpdateEmptyValues
-		return "", err
+		p.add(&ValidationError{Clause: "SET", Err: ErrUpdateEmptyValues})
 	} else if len(ub.columns) != len(ub.values) {
-		err = errUpdateColumnsValuesDiffLen
-		return "", err
-	} else if checkSameColumns(ub.columns) {
-		err = errUpdateColumnsSame
-		return "", err
+		p.add(&ValidationError{Clause: "SET", Err: ErrUpdateColumnsValuesDiffLen})
 	}
+	if duplicates := duplicateColumns(ub.columns); len(duplicates) > 0 {
+		p.add(&ValidationError{Clause: "SET", Columns: duplicates, Err: ErrUpdateColumnsSame})
+	}
+	if isEmptyCondition(ub.where) && !ub.allRows {
+		p.add(&ValidationError{Clause: "WHERE", Err: ErrUpdateWithoutWhere})
+	}
+	return p.err()
+}
 
-	sqlSet := make([]string, len(ub.columns))
+func (ub *UpdateBuilder) writeSet(w *sqlWriter) error {
+	w.clause = "SET"
+	w.WriteString(" SET ")
 	for i := 0; i < len(ub.columns) && i < len(ub.values); i++ {
-		sqlSet[i] = ub.columns[i] + " = " + ub.values[i]
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.writeIdent(ub.columns[i])
+		w.WriteString(" = ")
+		if err := w.writeValue(ub.values[i]); err != nil {
+			return err
+		}
 	}
+	w.clause = "RETURNING"
+	return w.writeOutput(ub.returning, "INSERTED")
+}
 
-	sql := "UPDATE " + ub.table + " SET " + strings.Join(sqlSet, ", ")
-	if len(ub.conditionsAnd) > 0 || len(ub.conditionsOr) > 0 {
-		sql += " WHERE "
-	}
-	if len(ub.conditionsAnd) > 0 {
-		sql += strings.Join(ub.conditionsAnd, " AND ")
-	}
-	if len(ub.conditionsOr) > 0 {
-		if len(ub.conditionsAnd) > 0 {
-			sql += " OR"
-		}
-		sql += " " + strings.Join(ub.conditionsOr, " OR ")
+func (ub *UpdateBuilder) writeWhereReturning(w *sqlWriter, where Condition) error {
+	w.clause = "WHERE"
+	if err := w.writeWhere(where); err != nil {
+		return err
 	}
-	return sql, err
+	w.clause = "RETURNING"
+	return w.writeReturning(ub.returning)
 }
 
 func Update(table string) *UpdateBuilder {
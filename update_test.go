@@ -1,6 +1,7 @@
 package sqlq
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -220,8 +221,8 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			[]string{},
-			"UPDATE users SET id = '1', name = 'sqlq', email = 'sqlq@valuppo.com'",
-			nil,
+			"",
+			ErrUpdateWithoutWhere,
 		},
 		{
 			"",
@@ -234,7 +235,7 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateEmptyTables,
+			ErrUpdateEmptyTables,
 		},
 		{
 			"users",
@@ -247,7 +248,7 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateEmptyColumns,
+			ErrUpdateEmptyColumns,
 		},
 		{
 			"users",
@@ -260,7 +261,7 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateEmptyValues,
+			ErrUpdateEmptyValues,
 		},
 		{
 			"users",
@@ -273,7 +274,7 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateColumnsValuesDiffLen,
+			ErrUpdateColumnsValuesDiffLen,
 		},
 		{
 			"users",
@@ -286,7 +287,7 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateColumnsValuesDiffLen,
+			ErrUpdateColumnsValuesDiffLen,
 		},
 		{
 			"users",
@@ -299,7 +300,7 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateColumnsSame,
+			ErrUpdateColumnsSame,
 		},
 	}
 	for _, table := range tables {
@@ -314,7 +315,7 @@ func TestUpdateBuilder_Sql(t *testing.T) {
 			update.Where(table.ConditionColumnsOr[i], table.ConditionOperatorsOr[i], table.ConditionValuesOr[i])
 		}
 		result, err := update.Sql()
-		if result != table.Output && err != table.Error {
+		if result != table.Output && !errors.Is(err, table.Error) {
 			t.Errorf("Expected %v\nGot %v\n", table.Output, result)
 			t.Errorf("Expected error %v\nGot %v\n", table.Error, err)
 		}
@@ -386,8 +387,8 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			[]string{},
 			[]string{},
 			[]string{},
-			"UPDATE users SET id = '1', name = 'sqlq', email = 'sqlq@valuppo.com'",
-			nil,
+			"",
+			ErrUpdateWithoutWhere,
 		},
 		{
 			"",
@@ -400,7 +401,7 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateEmptyTables,
+			ErrUpdateEmptyTables,
 		},
 		{
 			"users",
@@ -413,7 +414,7 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateEmptyColumns,
+			ErrUpdateEmptyColumns,
 		},
 		{
 			"users",
@@ -426,7 +427,7 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateEmptyValues,
+			ErrUpdateEmptyValues,
 		},
 		{
 			"users",
@@ -439,7 +440,7 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateColumnsValuesDiffLen,
+			ErrUpdateColumnsValuesDiffLen,
 		},
 		{
 			"users",
@@ -452,7 +453,7 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateColumnsValuesDiffLen,
+			ErrUpdateColumnsValuesDiffLen,
 		},
 		{
 			"users",
@@ -465,7 +466,7 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			[]string{},
 			[]string{},
 			"",
-			errUpdateColumnsSame,
+			ErrUpdateColumnsSame,
 		},
 	}
 	for _, table := range tables {
@@ -478,9 +479,199 @@ func TestUpdateBuilder_Sql2(t *testing.T) {
 			update.Where(table.ConditionColumnsOr[i], table.ConditionOperatorsOr[i], table.ConditionValuesOr[i])
 		}
 		result, err := update.Sql()
-		if result != table.Output && err != table.Error {
+		if result != table.Output && !errors.Is(err, table.Error) {
 			t.Errorf("Expected %v\nGot %v\n", table.Output, result)
 			t.Errorf("Expected error %v\nGot %v\n", table.Error, err)
 		}
 	}
 }
+
+func TestUpdateBuilder_ToSql(t *testing.T) {
+	tables := []struct {
+		Builder *UpdateBuilder
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			Update("users").Set("name", "O'Brien").Where("id", "=", "5").WhereOr("email", "LIKE", "%sqlq%"),
+			"UPDATE users SET name = ? WHERE id = ? OR email LIKE ?",
+			[]interface{}{"O'Brien", "5", "%sqlq%"},
+			nil,
+		},
+		{
+			Update("users"),
+			"",
+			nil,
+			ErrUpdateEmptyColumns,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v %v\nGot %v %v %v\n", table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestUpdateBuilder_SqlTypedValues(t *testing.T) {
+	tables := []struct {
+		Builder *UpdateBuilder
+		Output  string
+		Error   error
+	}{
+		{
+			Update("users").Set("age", 5).Set("active", true).Set("deleted_at", nil).Where("id", "=", 1),
+			"UPDATE users SET age = 5, active = TRUE, deleted_at = NULL WHERE id = 1",
+			nil,
+		},
+		{
+			Update("users").Set("active", false).Where("deleted_at", "=", nil).Dialect(SQLite),
+			"UPDATE users SET active = 0 WHERE deleted_at IS NULL",
+			nil,
+		},
+		{
+			Update("users").Set("meta", struct{}{}).AllRows(),
+			"",
+			ErrValueUnsupported,
+		},
+	}
+	for _, table := range tables {
+		result, err := table.Builder.Sql()
+		if result != table.Output || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v\nGot %v %v\n", table.Output, table.Error, result, err)
+		}
+	}
+}
+
+func TestUpdateBuilder_SqlRaw(t *testing.T) {
+	result, args, err := Update("posts").Set("updated_at", Raw("NOW()")).Set("hits", Raw("hits + ?", 1)).
+		Where("id", "=", 5).Dialect(PostgreSQL).ToSql()
+	if result != "UPDATE posts SET updated_at = NOW(), hits = hits + $1 WHERE id = $2" ||
+		!reflect.DeepEqual(args, []interface{}{1, 5}) || err != nil {
+		t.Errorf("Got %v %v %v", result, args, err)
+	}
+}
+
+func TestUpdateBuilder_WhereCond(t *testing.T) {
+	result, args, err := Update("users").Set("active", false).
+		WhereCond(Or(Cond("last_login", "<", "2020-01-01"), Cond("last_login", "=", nil))).Where("role", "<>", "admin").ToSql()
+	if result != "UPDATE users SET active = ? WHERE (last_login < ? OR last_login IS NULL) AND role <> ?" ||
+		!reflect.DeepEqual(args, []interface{}{false, "2020-01-01", "admin"}) || err != nil {
+		t.Errorf("Got %v %v %v", result, args, err)
+	}
+}
+
+func TestUpdateBuilder_With(t *testing.T) {
+	result, args, err := Update("users").Set("vip", true).Where("id", "IN", Select("user_id").From("big")).
+		With("big", Select("user_id").From("orders").Where("total", ">", 1000)).Dialect(PostgreSQL).ToSql()
+	if result != "WITH big AS (SELECT user_id FROM orders WHERE total > $1) UPDATE users SET vip = $2 WHERE id IN (SELECT user_id FROM big)" ||
+		!reflect.DeepEqual(args, []interface{}{1000, true}) || err != nil {
+		t.Errorf("Got %v %v %v", result, args, err)
+	}
+}
+
+func TestUpdateBuilder_Join(t *testing.T) {
+	orders := func() *UpdateBuilder {
+		return Update("orders").Set("status", "vip").Join("users", On("users.id", "=", "orders.user_id")).
+			Where("users.level", ">", 3)
+	}
+	tables := []struct {
+		Builder *UpdateBuilder
+		Dialect Dialect
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			orders(),
+			MySQL,
+			"UPDATE orders INNER JOIN users ON users.id = orders.user_id SET status = ? WHERE users.level > ?",
+			[]interface{}{"vip", 3},
+			nil,
+		},
+		{
+			orders().Set("total", Col("users.credit")),
+			PostgreSQL,
+			"UPDATE orders SET status = $1, total = users.credit FROM users WHERE users.id = orders.user_id AND users.level > $2",
+			[]interface{}{"vip", 3},
+			nil,
+		},
+		{
+			orders().LeftJoin(As("teams", "t"), On("t.id", "=", "users.team_id")).WhereOr("users.admin", "=", true),
+			SQLite,
+			"UPDATE orders SET status = ? FROM users LEFT JOIN teams AS t ON t.id = users.team_id " +
+				"WHERE users.id = orders.user_id AND (users.level > ? OR users.admin = ?)",
+			[]interface{}{"vip", 3, true},
+			nil,
+		},
+		{
+			orders().Returning("id"),
+			SQLServer,
+			"UPDATE orders SET status = @p1 OUTPUT INSERTED.id FROM orders INNER JOIN users ON users.id = orders.user_id WHERE users.level > @p2",
+			[]interface{}{"vip", 3},
+			nil,
+		},
+		{
+			Update("orders").Set("status", "vip").CrossJoin("settings").Where("settings.vip", "=", true),
+			PostgreSQL,
+			"UPDATE orders SET status = $1 FROM settings WHERE settings.vip = $2",
+			[]interface{}{"vip", true},
+			nil,
+		},
+		{
+			Update("orders").Set("status", "vip").LeftJoin("users", On("users.id", "=", "orders.user_id")).AllRows(),
+			PostgreSQL,
+			"",
+			nil,
+			ErrJoinFirst,
+		},
+		{
+			Update("orders").Set("status", "vip").Join("users", Using("user_id")).AllRows(),
+			SQLite,
+			"",
+			nil,
+			ErrJoinFirst,
+		},
+		{
+			Update("orders").Set("status", "vip").Join("users").AllRows(),
+			PostgreSQL,
+			"",
+			nil,
+			ErrJoinEmptyCondition,
+		},
+		{
+			orders(),
+			noWindowDialect{},
+			"",
+			nil,
+			ErrUpdateJoinUnsupported,
+		},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.Dialect(table.Dialect).ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v %v\nGot %v %v %v\n", table.Dialect.Name(), table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestUpdateBuilder_AllRows(t *testing.T) {
+	tables := []struct {
+		Builder *UpdateBuilder
+		Output  string
+		Error   error
+	}{
+		{Update("users").Set("active", false), "", ErrUpdateWithoutWhere},
+		{Update("users").Strict(false).Set("active", false).Where("id", "=", ""), "", ErrUpdateWithoutWhere},
+		{Update("users").Set("active", false).WhereCond(And()), "", ErrUpdateWithoutWhere},
+		{Update("users").Set("active", false).WhereCond(Raw(" ")), "", ErrUpdateWithoutWhere},
+		{Update("users").Set("active", false).AllRows(), "UPDATE users SET active = FALSE", nil},
+	}
+	for _, table := range tables {
+		result, err := table.Builder.Sql()
+		if result != table.Output || !errors.Is(err, table.Error) {
+			t.Errorf("Expected %v %v\nGot %v %v\n", table.Output, table.Error, result, err)
+		}
+	}
+}
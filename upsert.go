@@ -0,0 +1,213 @@
+package sqlq
+
+import (
+	"errors"
+)
+
+var ErrUpsertUnsupported = errors.New("Dialect doesn't support Upsert")
+var ErrUpsertActionRequired = errors.New("Upsert requires an action, use DoNothing() or DoUpdateSet() function to specify it")
+var ErrUpsertActionsBoth = errors.New("Upsert can't have both DoNothing() and DoUpdateSet()")
+var ErrUpsertTargetsBoth = errors.New("Upsert can't have both OnConflict() and OnConflictConstraint()")
+var ErrUpsertWhereWithoutUpdate = errors.New("DoUpdateWhere() requires DoUpdateSet()")
+var ErrUpsertTargetRequired = errors.New("Dialect requires conflict Columns for this Upsert, use OnConflict() function to specify Columns")
+var ErrUpsertTargetColumn = errors.New("Conflict Columns must be Insert Columns")
+var ErrUpsertConstraintUnsupported = errors.New("Dialect doesn't support conflict Constraint names, use OnConflict() function to specify Columns")
+var ErrUpsertWhereUnsupported = errors.New("Dialect doesn't support Where on Upsert update")
+
+// mergeSource is the alias of the inserted rows in a MERGE, Excluded() columns refer to it.
+const mergeSource = "sqlq_source"
+
+// ExcludedColumn is the value a conflicting row tried to insert, written as excluded.column,
+// VALUES(column) or the MERGE source column depending on the dialect.
+type ExcludedColumn string
+
+func Excluded(column string) ExcludedColumn {
+	return ExcludedColumn(column)
+}
+
+type assignment struct {
+	column string
+	value  interface{}
+}
+
+type upsert struct {
+	target     []string
+	constraint string
+	doNothing  bool
+	set        []assignment
+	where      Condition
+}
+
+func (ib *InsertBuilder) onConflict() *upsert {
+	if ib.upsert == nil {
+		ib.upsert = &upsert{}
+	}
+	return ib.upsert
+}
+
+// OnConflict makes the insert an upsert on rows that conflict on the unique columns.
+// MySQL ignores them and uses every unique key of the table.
+func (ib *InsertBuilder) OnConflict(columns ...string) *InsertBuilder {
+	ib.onConflict().target = append(ib.onConflict().target, columns...)
+	return ib
+}
+
+func (ib *InsertBuilder) OnConflictConstraint(name string) *InsertBuilder {
+	ib.onConflict().constraint = name
+	return ib
+}
+
+func (ib *InsertBuilder) DoNothing() *InsertBuilder {
+	ib.onConflict().doNothing = true
+	return ib
+}
+
+// DoUpdateSet updates column of the conflicting row, use Excluded() to get the value that was inserted.
+func (ib *InsertBuilder) DoUpdateSet(column string, value interface{}) *InsertBuilder {
+	ib.onConflict().set = append(ib.onConflict().set, assignment{column, value})
+	return ib
+}
+
+func (ib *InsertBuilder) DoUpdateWhere(column interface{}, operator string, value interface{}) *InsertBuilder {
+	if ib.check.allowCondition("ON CONFLICT", column, operator, value) {
+		ib.onConflict().where = joinConditions("AND", ib.onConflict().where, condition{column, operator, value})
+	}
+	return ib
+}
+
+func (ib *InsertBuilder) DoUpdateWhereCond(conditions ...Condition) *InsertBuilder {
+	ib.onConflict().where = joinConditions("AND", ib.onConflict().where, And(conditions...))
+	return ib
+}
+
+func (u *upsert) validate() error {
+	if !u.doNothing && len(u.set) <= 0 {
+		return ErrUpsertActionRequired
+	} else if u.doNothing && len(u.set) > 0 {
+		return ErrUpsertActionsBoth
+	} else if len(u.target) > 0 && u.constraint != "" {
+		return ErrUpsertTargetsBoth
+	} else if !isEmptyCondition(u.where) && len(u.set) <= 0 {
+		return ErrUpsertWhereWithoutUpdate
+	}
+	return nil
+}
+
+// merging reports whether the insert is written as a MERGE because the dialect has no upsert clause.
+func (ib *InsertBuilder) merging(dialect Dialect) bool {
+	return ib.upsert != nil && !dialect.Supports(FeatureOnConflict) && !dialect.Supports(FeatureOnDuplicateKey) &&
+		dialect.Supports(FeatureMerge)
+}
+
+// writeUpsert writes what follows the inserted rows, the whole rest of the statement for a MERGE.
+func (ib *InsertBuilder) writeUpsert(w *sqlWriter) error {
+	u := ib.upsert
+	if u == nil {
+		return nil
+	}
+	if u.constraint != "" && !w.dialect.Supports(FeatureOnConflictConstraint) {
+		return ErrUpsertConstraintUnsupported
+	}
+	switch {
+	case w.dialect.Supports(FeatureOnConflict):
+		w.WriteString(" ON CONFLICT")
+		if u.constraint != "" {
+			w.WriteString(" ON CONSTRAINT ")
+			w.writeIdent(u.constraint)
+		} else if len(u.target) > 0 {
+			w.WriteString(" (")
+			w.writeIdents(u.target)
+			w.WriteString(")")
+		} else if !u.doNothing {
+			return ErrUpsertTargetRequired
+		}
+		if u.doNothing {
+			w.WriteString(" DO NOTHING")
+			return nil
+		}
+		w.WriteString(" DO UPDATE SET ")
+		if err := w.writeAssignments(u.set); err != nil {
+			return err
+		}
+		return w.writeWhere(u.where)
+	case w.dialect.Supports(FeatureOnDuplicateKey):
+		if !isEmptyCondition(u.where) {
+			return ErrUpsertWhereUnsupported
+		}
+		w.WriteString(" ON DUPLICATE KEY UPDATE ")
+		if u.doNothing {
+			// updating a column to itself leaves the row as it is
+			return w.writeAssignments([]assignment{{ib.columns[0], Col(ib.columns[0])}})
+		}
+		return w.writeAssignments(u.set)
+	case w.dialect.Supports(FeatureMerge):
+		return ib.writeMerge(w)
+	}
+	return ErrUpsertUnsupported
+}
+
+// writeMerge writes the MERGE after its source, the source rows are matched on the conflict columns.
+func (ib *InsertBuilder) writeMerge(w *sqlWriter) error {
+	u := ib.upsert
+	if len(u.target) <= 0 {
+		return ErrUpsertTargetRequired
+	}
+	w.WriteString(") AS " + mergeSource + " (")
+	w.writeIdents(ib.columns)
+	w.WriteString(") ON ")
+	for i, column := range u.target {
+		if !containsString(ib.columns, column) {
+			return ErrUpsertTargetColumn
+		}
+		if i > 0 {
+			w.WriteString(" AND ")
+		}
+		w.writeIdent(ib.table + "." + column)
+		w.WriteString(" = ")
+		w.writeIdent(mergeSource + "." + column)
+	}
+	if !u.doNothing {
+		w.WriteString(" WHEN MATCHED")
+		if !isEmptyCondition(u.where) {
+			w.WriteString(" AND ")
+			if err := unwrapCondition(u.where).writeCondition(w); err != nil {
+				return err
+			}
+		}
+		w.WriteString(" THEN UPDATE SET ")
+		if err := w.writeAssignments(u.set); err != nil {
+			return err
+		}
+	}
+	w.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	w.writeIdents(ib.columns)
+	w.WriteString(") VALUES (")
+	for i, column := range ib.columns {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.writeIdent(mergeSource + "." + column)
+	}
+	w.WriteString(")")
+	if err := w.writeOutput(ib.returning, "INSERTED"); err != nil {
+		return err
+	}
+	w.WriteString(";")
+	return nil
+}
+
+func (w *sqlWriter) writeExcluded(column ExcludedColumn) error {
+	switch {
+	case w.dialect.Supports(FeatureOnConflict):
+		w.writeIdent("excluded." + string(column))
+	case w.dialect.Supports(FeatureOnDuplicateKey):
+		w.WriteString("VALUES(")
+		w.writeIdent(string(column))
+		w.WriteString(")")
+	case w.dialect.Supports(FeatureMerge):
+		w.writeIdent(mergeSource + "." + string(column))
+	default:
+		return ErrUpsertUnsupported
+	}
+	return nil
+}
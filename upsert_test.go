@@ -0,0 +1,160 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInsertBuilder_Upsert(t *testing.T) {
+	users := func() *InsertBuilder {
+		return Insert().Into("users").Columns("email", "name").Values("a@b.c", "a")
+	}
+	tables := []struct {
+		Builder *InsertBuilder
+		Dialect Dialect
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			users().OnConflict("email").DoUpdateSet("name", Excluded("name")),
+			PostgreSQL,
+			"INSERT INTO users (email, name) VALUES ($1, $2) ON CONFLICT (email) DO UPDATE SET name = excluded.name",
+			[]interface{}{"a@b.c", "a"},
+			nil,
+		},
+		{
+			users().OnConflict("email").DoUpdateSet("name", Excluded("name")).DoUpdateSet("visits", Raw("users.visits + ?", 1)).
+				DoUpdateWhere("users.active", "=", true),
+			SQLite,
+			"INSERT INTO users (email, name) VALUES (?, ?) ON CONFLICT (email) DO UPDATE SET name = excluded.name, visits = users.visits + ? WHERE users.active = ?",
+			[]interface{}{"a@b.c", "a", 1, true},
+			nil,
+		},
+		{
+			users().DoNothing(),
+			SQLite,
+			"INSERT INTO users (email, name) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			[]interface{}{"a@b.c", "a"},
+			nil,
+		},
+		{
+			users().OnConflictConstraint("users_email_key").DoNothing(),
+			PostgreSQL,
+			"INSERT INTO users (email, name) VALUES ($1, $2) ON CONFLICT ON CONSTRAINT users_email_key DO NOTHING",
+			[]interface{}{"a@b.c", "a"},
+			nil,
+		},
+		{
+			users().OnConflict("email").DoUpdateSet("name", Excluded("name")),
+			MySQL,
+			"INSERT INTO users (email, name) VALUES (?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)",
+			[]interface{}{"a@b.c", "a"},
+			nil,
+		},
+		{
+			users().DoNothing(),
+			MySQL,
+			"INSERT INTO users (email, name) VALUES (?, ?) ON DUPLICATE KEY UPDATE email = email",
+			[]interface{}{"a@b.c", "a"},
+			nil,
+		},
+		{
+			users().Values("d@e.f", "d").OnConflict("email").DoUpdateSet("name", Excluded("name")).DoUpdateWhere("users.active", "=", true),
+			SQLServer,
+			"MERGE INTO users USING (VALUES (@p1, @p2), (@p3, @p4)) AS sqlq_source (email, name) ON users.email = sqlq_source.email " +
+				"WHEN MATCHED AND users.active = @p5 THEN UPDATE SET name = sqlq_source.name " +
+				"WHEN NOT MATCHED THEN INSERT (email, name) VALUES (sqlq_source.email, sqlq_source.name);",
+			[]interface{}{"a@b.c", "a", "d@e.f", "d", true},
+			nil,
+		},
+		{
+			users().OnConflict("email").DoNothing(),
+			SQLServer,
+			"MERGE INTO users USING (VALUES (@p1, @p2)) AS sqlq_source (email, name) ON users.email = sqlq_source.email " +
+				"WHEN NOT MATCHED THEN INSERT (email, name) VALUES (sqlq_source.email, sqlq_source.name);",
+			[]interface{}{"a@b.c", "a"},
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("email", "name").FromSelect(Select("email", "name").From("users")).
+				OnConflict("email").DoNothing(),
+			SQLServer,
+			"MERGE INTO archive USING (SELECT email, name FROM users) AS sqlq_source (email, name) ON archive.email = sqlq_source.email " +
+				"WHEN NOT MATCHED THEN INSERT (email, name) VALUES (sqlq_source.email, sqlq_source.name);",
+			nil,
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("email", "name").FromSelect(Select("email", "name").From("users")).
+				OnConflict("email").DoNothing(),
+			SQLite,
+			"INSERT INTO archive (email, name) SELECT email, name FROM users WHERE true ON CONFLICT (email) DO NOTHING",
+			nil,
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("email", "name").FromSelect(Select("email", "name").From("users").Where("active", "=", true)).
+				OnConflict("email").DoNothing(),
+			SQLite,
+			"INSERT INTO archive (email, name) SELECT email, name FROM users WHERE active = ? ON CONFLICT (email) DO NOTHING",
+			[]interface{}{true},
+			nil,
+		},
+		{
+			Insert().Into("archive").Columns("email", "name").FromSelect(Select("email", "name").From("users")).
+				OnConflict("email").DoNothing(),
+			PostgreSQL,
+			"INSERT INTO archive (email, name) SELECT email, name FROM users ON CONFLICT (email) DO NOTHING",
+			nil,
+			nil,
+		},
+		{users().OnConflict("email"), PostgreSQL, "", nil, ErrUpsertActionRequired},
+		{users().DoNothing().DoUpdateSet("name", "b"), PostgreSQL, "", nil, ErrUpsertActionsBoth},
+		{users().OnConflict("email").OnConflictConstraint("users_email_key").DoNothing(), PostgreSQL, "", nil, ErrUpsertTargetsBoth},
+		{users().OnConflict("email").DoNothing().DoUpdateWhere("active", "=", true), PostgreSQL, "", nil, ErrUpsertWhereWithoutUpdate},
+		{users().DoUpdateSet("name", Excluded("name")), PostgreSQL, "", nil, ErrUpsertTargetRequired},
+		{users().DoNothing(), SQLServer, "", nil, ErrUpsertTargetRequired},
+		{users().OnConflict("id").DoNothing(), SQLServer, "", nil, ErrUpsertTargetColumn},
+		{users().OnConflictConstraint("users_email_key").DoNothing(), SQLite, "", nil, ErrUpsertConstraintUnsupported},
+		{users().OnConflictConstraint("users_email_key").DoNothing(), MySQL, "", nil, ErrUpsertConstraintUnsupported},
+		{users().DoUpdateSet("name", "b").DoUpdateWhere("active", "=", true), MySQL, "", nil, ErrUpsertWhereUnsupported},
+		{users().OnConflict("email").DoNothing(), noWindowDialect{}, "", nil, ErrUpsertUnsupported},
+	}
+	for _, table := range tables {
+		result, args, err := table.Builder.Dialect(table.Dialect).ToSql()
+		if result != table.Output || !reflect.DeepEqual(args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v %v\nGot %v %v %v\n", table.Dialect.Name(), table.Output, table.Args, table.Error, result, args, err)
+		}
+	}
+}
+
+func TestInsertBuilder_UpsertBatches(t *testing.T) {
+	statements, err := Insert().Into("users").Columns("email").Values("a").Values("b").Values("c").
+		OnConflict("email").DoUpdateSet("email", Excluded("email")).Dialect(SQLServer).
+		Batches(len("MERGE INTO users USING (VALUES (@p1), (@p2)) AS sqlq_source (email) ON users.email = sqlq_source.email " +
+			"WHEN MATCHED THEN UPDATE SET email = sqlq_source.email WHEN NOT MATCHED THEN INSERT (email) VALUES (sqlq_source.email);"))
+	expected := []Statement{
+		{"MERGE INTO users USING (VALUES (@p1), (@p2)) AS sqlq_source (email) ON users.email = sqlq_source.email " +
+			"WHEN MATCHED THEN UPDATE SET email = sqlq_source.email WHEN NOT MATCHED THEN INSERT (email) VALUES (sqlq_source.email);",
+			[]interface{}{"a", "b"}},
+		{"MERGE INTO users USING (VALUES (@p1)) AS sqlq_source (email) ON users.email = sqlq_source.email " +
+			"WHEN MATCHED THEN UPDATE SET email = sqlq_source.email WHEN NOT MATCHED THEN INSERT (email) VALUES (sqlq_source.email);",
+			[]interface{}{"c"}},
+	}
+	if !reflect.DeepEqual(statements, expected) || err != nil {
+		t.Errorf("Expected %v\nGot %v %v\n", expected, statements, err)
+	}
+
+	statements, err = Insert().Into("users").Columns("email").Values("a").Values("b").Values("c").
+		OnConflict("email").DoUpdateSet("visits", Raw("visits + ?", 1)).Dialect(PostgreSQL).
+		Batches(len("INSERT INTO users (email) VALUES ($1), ($2) ON CONFLICT (email) DO UPDATE SET visits = visits + $65536"))
+	expected = []Statement{
+		{"INSERT INTO users (email) VALUES ($1), ($2) ON CONFLICT (email) DO UPDATE SET visits = visits + $3", []interface{}{"a", "b", 1}},
+		{"INSERT INTO users (email) VALUES ($1) ON CONFLICT (email) DO UPDATE SET visits = visits + $2", []interface{}{"c", 1}},
+	}
+	if !reflect.DeepEqual(statements, expected) || err != nil {
+		t.Errorf("Expected %v\nGot %v %v\n", expected, statements, err)
+	}
+}
@@ -1,11 +1,74 @@
 package sqlq
 
+import (
+	"regexp"
+	"strings"
+)
+
+var aggregateRegexp = regexp.MustCompile(`(?i)\b(COUNT|SUM|AVG|MIN|MAX|GROUP_CONCAT|STRING_AGG|ARRAY_AGG|JSON_AGG|JSON_ARRAYAGG|BOOL_AND|BOOL_OR|EVERY)\s*\(`)
+
 func checkSameColumns(columns []string) bool {
+	return len(duplicateColumns(columns)) > 0
+}
+
+// duplicateColumns returns every column that appears more than once, each of them once.
+func duplicateColumns(columns []string) []string {
+	var duplicates []string
 	for i := 0; i < len(columns); i++ {
 		for j := i + 1; j < len(columns); j++ {
-			if columns[i] == columns[j] {
+			if columns[i] == columns[j] && !containsString(duplicates, columns[i]) {
+				duplicates = append(duplicates, columns[i])
+			}
+		}
+	}
+	return duplicates
+}
+
+func stringColumns(columns []interface{}) []string {
+	var names []string
+	for _, column := range columns {
+		if name, ok := column.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func hasAggregate(columns []interface{}) bool {
+	for _, column := range columns {
+		switch c := column.(type) {
+		case Expr:
+			if aggregateRegexp.MatchString(c.sql) {
 				return true
 			}
+		case aliased:
+			if hasAggregate([]interface{}{c.value}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// columnCount counts the columns a select returns, ok is false when a * or a Raw() column makes it unknown.
+func columnCount(columns []interface{}) (count int, ok bool) {
+	for _, column := range columns {
+		switch c := column.(type) {
+		case string:
+			if strings.HasSuffix(c, "*") {
+				return 0, false
+			}
+		case Expr:
+			return 0, false
+		}
+	}
+	return len(columns), true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
 		}
 	}
 	return false
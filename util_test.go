@@ -1,6 +1,9 @@
 package sqlq
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestCheckSameColumns(t *testing.T) {
 	tables := []struct{
@@ -50,4 +53,79 @@ func TestCheckSameColumns(t *testing.T) {
 			t.Errorf("Expected %v got %v", table.Output, result)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestStringColumns(t *testing.T) {
+	tables := []struct {
+		Input  []interface{}
+		Output []string
+	}{
+		{
+			[]interface{}{},
+			nil,
+		},
+		{
+			[]interface{}{"id", Raw("COUNT(*)"), "name"},
+			[]string{"id", "name"},
+		},
+	}
+	for _, table := range tables {
+		result := stringColumns(table.Input)
+		if !reflect.DeepEqual(result, table.Output) {
+			t.Errorf("Expected %v got %v", table.Output, result)
+		}
+	}
+}
+
+func TestHasAggregate(t *testing.T) {
+	tables := []struct {
+		Input  []interface{}
+		Output bool
+	}{
+		{
+			[]interface{}{"id", "name"},
+			false,
+		},
+		{
+			[]interface{}{"id", "count"},
+			false,
+		},
+		{
+			[]interface{}{"id", Raw("SUM(total)")},
+			true,
+		},
+		{
+			[]interface{}{As(Raw("MAX (total)"), "top")},
+			true,
+		},
+		{
+			[]interface{}{"discount"},
+			false,
+		},
+	}
+	for _, table := range tables {
+		result := hasAggregate(table.Input)
+		if result != table.Output {
+			t.Errorf("Expected %v for %v got %v", table.Output, table.Input, result)
+		}
+	}
+}
+
+func TestColumnCount(t *testing.T) {
+	tables := []struct {
+		Input []interface{}
+		Count int
+		Known bool
+	}{
+		{[]interface{}{"id", "name"}, 2, true},
+		{[]interface{}{"id", As(Raw("COUNT(*)"), "total"), Select("1").From("t")}, 3, true},
+		{[]interface{}{"u.*"}, 0, false},
+		{[]interface{}{"*"}, 0, false},
+		{[]interface{}{"id", Raw("COUNT(*)")}, 0, false},
+	}
+	for _, table := range tables {
+		count, known := columnCount(table.Input)
+		if count != table.Count || known != table.Known {
+			t.Errorf("Expected %v %v for %v got %v %v", table.Count, table.Known, table.Input, count, known)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package sqlq
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrValueUnsupported = errors.New("Value type can't be written as SQL literal, use ToSql() to pass it as argument")
+
+func resolveValue(value interface{}) (interface{}, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, nil
+		}
+		return valuer.Value()
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+		value = rv.Interface()
+		if valuer, ok := value.(driver.Valuer); ok {
+			return valuer.Value()
+		}
+	}
+	return value, nil
+}
+
+func isNull(value interface{}) (bool, error) {
+	value, err := resolveValue(value)
+	return value == nil, err
+}
+
+func literal(dialect Dialect, value interface{}) (string, error) {
+	value, err := resolveValue(value)
+	if err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return dialect.StringLiteral(v), nil
+	case []byte:
+		return dialect.BytesLiteral(v), nil
+	case bool:
+		return dialect.BoolLiteral(v), nil
+	case time.Time:
+		return dialect.TimeLiteral(v), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return dialect.StringLiteral(rv.String()), nil
+	case reflect.Bool:
+		return dialect.BoolLiteral(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	}
+	return "", ErrValueUnsupported
+}
+
+func quoteString(value string) string {
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
@@ -0,0 +1,74 @@
+package sqlq
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+type status int
+
+func TestLiteral(t *testing.T) {
+	var nilTime *time.Time
+	name := "sqlq"
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tables := []struct {
+		Dialect Dialect
+		Value   interface{}
+		Output  string
+		Error   error
+	}{
+		{MySQL, nil, "NULL", nil},
+		{MySQL, nilTime, "NULL", nil},
+		{MySQL, 5, "5", nil},
+		{MySQL, int64(-5), "-5", nil},
+		{MySQL, uint8(5), "5", nil},
+		{MySQL, 1.5, "1.5", nil},
+		{MySQL, status(2), "2", nil},
+		{MySQL, "O'Brien", "'O''Brien'", nil},
+		{MySQL, `a\'b`, `'a\\''b'`, nil},
+		{PostgreSQL, `a\b`, `'a\b'`, nil},
+		{SQLServer, "sqlq", "N'sqlq'", nil},
+		{MySQL, &name, "'sqlq'", nil},
+		{MySQL, true, "TRUE", nil},
+		{SQLite, true, "1", nil},
+		{MySQL, []byte{0xde, 0xad}, "X'dead'", nil},
+		{PostgreSQL, []byte{0xde, 0xad}, `'\xdead'`, nil},
+		{SQLServer, []byte{0xde, 0xad}, "0xdead", nil},
+		{MySQL, created, "'2020-01-02 03:04:05'", nil},
+		{PostgreSQL, created, "'2020-01-02 03:04:05+00:00'", nil},
+		{SQLServer, created, "'2020-01-02T03:04:05'", nil},
+		{MySQL, sql.NullString{String: "sqlq", Valid: true}, "'sqlq'", nil},
+		{MySQL, sql.NullInt64{}, "NULL", nil},
+		{MySQL, struct{}{}, "", ErrValueUnsupported},
+	}
+	for _, table := range tables {
+		result, err := literal(table.Dialect, table.Value)
+		if result != table.Output || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v got %v %v", table.Dialect.Name(), table.Output, table.Error, result, err)
+		}
+	}
+}
+
+func TestIsNull(t *testing.T) {
+	var nilString *string
+	tables := []struct {
+		Value  interface{}
+		Output bool
+	}{
+		{nil, true},
+		{nilString, true},
+		{sql.NullString{}, true},
+		{&sql.NullString{}, true},
+		{sql.NullString{Valid: true}, false},
+		{"", false},
+		{0, false},
+	}
+	for _, table := range tables {
+		result, err := isNull(table.Value)
+		if result != table.Output || err != nil {
+			t.Errorf("Expected %v for %#v got %v %v", table.Output, table.Value, result, err)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package sqlq
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrWindowUnsupported = errors.New("Dialect doesn't support Window Functions")
+var ErrWindowClauseUnsupported = errors.New("Dialect doesn't support named Window definitions, use Over() with the Window instead of its name")
+var ErrWindowFrameUnsupported = errors.New("Dialect doesn't support this Window Frame")
+var ErrWindowFrameInvalid = errors.New("Window Frame can't start after it ends and its offset can't be negative value")
+var ErrWindowEmptyName = errors.New("Name is required to do Window definition, use Window() function to specify Name")
+var ErrWindowEmptyFunction = errors.New("Function is required to do Over Operation")
+var ErrWindowNil = errors.New("Window can't be nil, use Window() function to build the Window definition")
+var ErrWindowFunctionInvalid = errors.New("Function must be a call like RANK() or SUM(total) with columns, * or numbers as arguments, use Raw() for other expressions")
+
+var functionCallRegexp = regexp.MustCompile(`^\s*([\p{L}_][\p{L}\p{N}_]*)\s*\((.*)\)\s*$`)
+
+type FrameBound struct {
+	kind   int
+	offset int
+}
+
+const (
+	boundUnboundedPreceding = iota
+	boundPreceding
+	boundCurrentRow
+	boundFollowing
+	boundUnboundedFollowing
+)
+
+var (
+	UnboundedPreceding = FrameBound{kind: boundUnboundedPreceding}
+	CurrentRow         = FrameBound{kind: boundCurrentRow}
+	UnboundedFollowing = FrameBound{kind: boundUnboundedFollowing}
+)
+
+func Preceding(offset int) FrameBound {
+	return FrameBound{boundPreceding, offset}
+}
+
+func Following(offset int) FrameBound {
+	return FrameBound{boundFollowing, offset}
+}
+
+func (fb FrameBound) String() string {
+	switch fb.kind {
+	case boundUnboundedPreceding:
+		return "UNBOUNDED PRECEDING"
+	case boundPreceding:
+		return strconv.Itoa(fb.offset) + " PRECEDING"
+	case boundFollowing:
+		return strconv.Itoa(fb.offset) + " FOLLOWING"
+	case boundUnboundedFollowing:
+		return "UNBOUNDED FOLLOWING"
+	}
+	return "CURRENT ROW"
+}
+
+type windowFrame struct {
+	unit  string
+	start FrameBound
+	end   FrameBound
+}
+
+type WindowBuilder struct {
+	base        string
+	partitionBy []interface{}
+	order       []orderBy
+	frame       *windowFrame
+}
+
+type windowFunction struct {
+	function interface{}
+	window   *WindowBuilder
+	name     string
+	// named is true when the window is referenced by name with OverWindow()
+	named bool
+}
+
+type namedWindow struct {
+	name   string
+	window *WindowBuilder
+}
+
+// Window creates a window definition, use it with Over() or SelectBuilder.Window().
+// The optional base is the name of another window definition this one extends.
+func Window(base ...string) *WindowBuilder {
+	wb := &WindowBuilder{}
+	if len(base) > 0 {
+		wb.base = base[0]
+	}
+	return wb
+}
+
+func (wb *WindowBuilder) PartitionBy(columns ...interface{}) *WindowBuilder {
+	wb.partitionBy = append(wb.partitionBy, columns...)
+	return wb
+}
+
+func (wb *WindowBuilder) OrderBy(column interface{}, order string) *WindowBuilder {
+	wb.order = append(wb.order, orderBy{column, order})
+	return wb
+}
+
+func (wb *WindowBuilder) Rows(start FrameBound, end FrameBound) *WindowBuilder {
+	wb.frame = &windowFrame{"ROWS", start, end}
+	return wb
+}
+
+func (wb *WindowBuilder) Range(start FrameBound, end FrameBound) *WindowBuilder {
+	wb.frame = &windowFrame{"RANGE", start, end}
+	return wb
+}
+
+// Over calls a window function like ROW_NUMBER() or Raw("SUM(total)") over a window definition.
+func Over(function interface{}, window *WindowBuilder) interface{} {
+	return windowFunction{function: function, window: window}
+}
+
+// OverWindow calls a window function over a window named with SelectBuilder.Window().
+func OverWindow(function interface{}, name string) interface{} {
+	return windowFunction{function: function, name: name, named: true}
+}
+
+func (w *sqlWriter) writeWindowFunction(wf windowFunction) error {
+	if !w.dialect.Supports(FeatureWindow) {
+		return ErrWindowUnsupported
+	}
+	if wf.function == nil || wf.function == "" {
+		return ErrWindowEmptyFunction
+	}
+	// a string is the call of the function like ROW_NUMBER(), not a column
+	if function, ok := wf.function.(string); ok {
+		if err := w.writeFunctionCall(function); err != nil {
+			return err
+		}
+	} else if err := w.writeColumn(wf.function); err != nil {
+		return err
+	}
+	w.WriteString(" OVER ")
+	if wf.named {
+		if wf.name == "" {
+			return ErrWindowEmptyName
+		}
+		if !w.dialect.Supports(FeatureWindowClause) {
+			return ErrWindowClauseUnsupported
+		}
+		w.writeAlias(wf.name)
+		return nil
+	}
+	w.WriteString("(")
+	if err := w.writeWindow(wf.window); err != nil {
+		return err
+	}
+	w.WriteString(")")
+	return nil
+}
+
+// writeFunctionCall writes a call like COUNT(*) or LAG(price, 1), its arguments are validated like columns.
+func (w *sqlWriter) writeFunctionCall(function string) error {
+	match := functionCallRegexp.FindStringSubmatch(function)
+	if match == nil {
+		return ErrWindowFunctionInvalid
+	}
+	w.WriteString(match[1] + "(")
+	if args := strings.TrimSpace(match[2]); args != "" {
+		for i, arg := range strings.Split(args, ",") {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			if arg = strings.TrimSpace(arg); numberRegexp.MatchString(arg) {
+				w.WriteString(arg)
+				continue
+			}
+			quoted, err := w.quoteIdent(arg, true, false)
+			if err != nil {
+				return ErrWindowFunctionInvalid
+			}
+			w.WriteString(quoted)
+		}
+	}
+	w.WriteString(")")
+	return nil
+}
+
+func (w *sqlWriter) writeWindow(wb *WindowBuilder) error {
+	if wb == nil {
+		return ErrWindowNil
+	}
+	separator := ""
+	if wb.base != "" {
+		if !w.dialect.Supports(FeatureWindowClause) {
+			return ErrWindowClauseUnsupported
+		}
+		w.writeAlias(wb.base)
+		separator = " "
+	}
+	if len(wb.partitionBy) > 0 {
+		w.WriteString(separator + "PARTITION BY ")
+		if err := w.writeColumns(wb.partitionBy); err != nil {
+			return err
+		}
+		separator = " "
+	}
+	if len(wb.order) > 0 {
+		w.WriteString(separator)
+		if err := w.writeOrderBy(wb.order); err != nil {
+			return err
+		}
+		separator = " "
+	}
+	if wb.frame != nil {
+		if err := wb.frame.validate(w.dialect); err != nil {
+			return err
+		}
+		w.WriteString(separator + wb.frame.unit + " BETWEEN " + wb.frame.start.String() + " AND " + wb.frame.end.String())
+	}
+	return nil
+}
+
+func (w *sqlWriter) writeNamedWindows(windows []namedWindow) error {
+	for i, nw := range windows {
+		if i == 0 {
+			if !w.dialect.Supports(FeatureWindowClause) {
+				return ErrWindowClauseUnsupported
+			}
+			w.WriteString(" WINDOW ")
+		} else {
+			w.WriteString(", ")
+		}
+		if nw.name == "" {
+			return ErrWindowEmptyName
+		}
+		w.writeAlias(nw.name)
+		w.WriteString(" AS (")
+		if err := w.writeWindow(nw.window); err != nil {
+			return err
+		}
+		w.WriteString(")")
+	}
+	return nil
+}
+
+func (f windowFrame) validate(dialect Dialect) error {
+	if f.start.kind > f.end.kind || f.start.kind == boundUnboundedFollowing || f.end.kind == boundUnboundedPreceding ||
+		f.start.offset < 0 || f.end.offset < 0 {
+		return ErrWindowFrameInvalid
+	}
+	if !dialect.Supports(FeatureWindowFrame) {
+		return ErrWindowFrameUnsupported
+	}
+	hasOffset := f.start.kind == boundPreceding || f.start.kind == boundFollowing ||
+		f.end.kind == boundPreceding || f.end.kind == boundFollowing
+	if f.unit == "RANGE" && hasOffset && !dialect.Supports(FeatureWindowRangeOffset) {
+		return ErrWindowFrameUnsupported
+	}
+	return nil
+}
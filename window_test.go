@@ -0,0 +1,227 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	tables := []struct {
+		Result *WindowBuilder
+		Output *WindowBuilder
+	}{
+		{
+			Window(),
+			&WindowBuilder{},
+		},
+		{
+			Window("w").PartitionBy("dept").OrderBy("salary", "DESC").Rows(UnboundedPreceding, CurrentRow),
+			&WindowBuilder{"w", []interface{}{"dept"}, []orderBy{{"salary", "DESC"}}, &windowFrame{"ROWS", UnboundedPreceding, CurrentRow}},
+		},
+	}
+	for _, table := range tables {
+		if !reflect.DeepEqual(table.Result, table.Output) {
+			t.Errorf("Expected %v got %v", table.Output, table.Result)
+		}
+	}
+}
+
+func TestSqlWriter_WriteWindowFunction(t *testing.T) {
+	tables := []struct {
+		Column  interface{}
+		Dialect Dialect
+		Output  string
+		Error   error
+	}{
+		{
+			Over("ROW_NUMBER()", Window().PartitionBy("dept").OrderBy("salary", "DESC")),
+			PostgreSQL,
+			"ROW_NUMBER() OVER (PARTITION BY dept ORDER BY salary DESC)",
+			nil,
+		},
+		{
+			As(Over(Raw("SUM(total)"), Window().OrderBy("created_at", "ASC").Rows(UnboundedPreceding, CurrentRow)), "running"),
+			MySQL,
+			"SUM(total) OVER (ORDER BY created_at ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS running",
+			nil,
+		},
+		{
+			Over("AVG(price)", Window().OrderBy("day", "ASC").Range(Preceding(7), Following(0))),
+			SQLite,
+			"AVG(price) OVER (ORDER BY day ASC RANGE BETWEEN 7 PRECEDING AND 0 FOLLOWING)",
+			nil,
+		},
+		{
+			Over("COUNT(*)", Window()),
+			SQLServer,
+			"COUNT(*) OVER ()",
+			nil,
+		},
+		{
+			Over("AVG(price)", Window().OrderBy("day", "ASC").Rows(Preceding(6), CurrentRow)),
+			SQLServer,
+			"AVG(price) OVER (ORDER BY day ASC ROWS BETWEEN 6 PRECEDING AND CURRENT ROW)",
+			nil,
+		},
+		{
+			OverWindow("RANK()", "w"),
+			PostgreSQL,
+			"RANK() OVER w",
+			nil,
+		},
+		{
+			Over("RANK()", Window("w").OrderBy("id", "ASC")),
+			PostgreSQL,
+			"RANK() OVER (w ORDER BY id ASC)",
+			nil,
+		},
+		{
+			OverWindow("RANK()", "w"),
+			SQLServer,
+			"RANK() OVER ",
+			ErrWindowClauseUnsupported,
+		},
+		{
+			Over("AVG(price)", Window().OrderBy("day", "ASC").Range(Preceding(7), CurrentRow)),
+			SQLServer,
+			"AVG(price) OVER (ORDER BY day ASC",
+			ErrWindowFrameUnsupported,
+		},
+		{
+			Over("SUM(total)", Window().Rows(UnboundedPreceding, CurrentRow)),
+			SQLServer2008,
+			"SUM(total) OVER (",
+			ErrWindowFrameUnsupported,
+		},
+		{
+			Over("SUM(total)", Window().Rows(CurrentRow, UnboundedPreceding)),
+			PostgreSQL,
+			"SUM(total) OVER (",
+			ErrWindowFrameInvalid,
+		},
+		{
+			Over("SUM(total)", Window().Rows(Preceding(-1), CurrentRow)),
+			PostgreSQL,
+			"SUM(total) OVER (",
+			ErrWindowFrameInvalid,
+		},
+		{
+			Over("", Window()),
+			PostgreSQL,
+			"",
+			ErrWindowEmptyFunction,
+		},
+		{
+			Over("lag( price , 1 )", Window().OrderBy("day", "ASC")),
+			PostgreSQL,
+			"lag(price, 1) OVER (ORDER BY day ASC)",
+			nil,
+		},
+		{
+			Over("MAX(t.order)", Window()),
+			MySQL,
+			"MAX(t.`order`) OVER ()",
+			nil,
+		},
+		{
+			Over("ROW_NUMBER() ; DROP TABLE users --", Window()),
+			PostgreSQL,
+			"",
+			ErrWindowFunctionInvalid,
+		},
+		{
+			Over("SUM(total) FILTER (WHERE 1 = 1)", Window()),
+			PostgreSQL,
+			"SUM(",
+			ErrWindowFunctionInvalid,
+		},
+		{
+			Over("SUM(total); --)", Window()),
+			PostgreSQL,
+			"SUM(",
+			ErrWindowFunctionInvalid,
+		},
+		{
+			OverWindow("RANK()", ""),
+			PostgreSQL,
+			"RANK() OVER ",
+			ErrWindowEmptyName,
+		},
+		{
+			Over("ROW_NUMBER()", Window()),
+			noWindowDialect{},
+			"",
+			ErrWindowUnsupported,
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(table.Dialect, false)
+		err := w.writeColumn(table.Column)
+		if w.String() != table.Output || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v got %v %v", table.Dialect.Name(), table.Output, table.Error, w.String(), err)
+		}
+	}
+}
+
+type noWindowDialect struct {
+	mysqlDialect
+}
+
+func (noWindowDialect) Supports(feature Feature) bool {
+	return false
+}
+
+func TestSelectBuilder_Window(t *testing.T) {
+	tables := []struct {
+		Builder *SelectBuilder
+		Dialect Dialect
+		Output  string
+		Error   error
+	}{
+		{
+			Select("id", As(OverWindow("ROW_NUMBER()", "w"), "rn"), As(OverWindow("SUM(salary)", "w"), "total")).From("employees").
+				Window("w", Window().PartitionBy("dept").OrderBy("salary", "DESC")).OrderBy(OverWindow("RANK()", "w"), "ASC"),
+			PostgreSQL,
+			"SELECT id, ROW_NUMBER() OVER w AS rn, SUM(salary) OVER w AS total FROM employees " +
+				"WINDOW w AS (PARTITION BY dept ORDER BY salary DESC) ORDER BY RANK() OVER w ASC",
+			nil,
+		},
+		{
+			Select("id").From("employees").Window("w", Window().PartitionBy("dept")).Window("w2", Window("w").OrderBy("id", "ASC")),
+			MySQL,
+			"SELECT id FROM employees WINDOW w AS (PARTITION BY dept), w2 AS (w ORDER BY id ASC)",
+			nil,
+		},
+		{
+			Select("id").From("employees").Window("w", Window().PartitionBy("dept")),
+			SQLServer,
+			"",
+			ErrWindowClauseUnsupported,
+		},
+		{
+			Select("id").From("employees").Window("", Window().PartitionBy("dept")),
+			MySQL,
+			"",
+			ErrWindowEmptyName,
+		},
+		{
+			Select("a").From("t").Window("w", nil),
+			PostgreSQL,
+			"",
+			ErrWindowNil,
+		},
+		{
+			Select("a", Over("ROW_NUMBER()", nil)).From("t"),
+			PostgreSQL,
+			"",
+			ErrWindowNil,
+		},
+	}
+	for _, table := range tables {
+		result, err := table.Builder.Dialect(table.Dialect).Sql()
+		if result != table.Output || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v\nGot %v %v\n", table.Dialect.Name(), table.Output, table.Error, result, err)
+		}
+	}
+}
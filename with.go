@@ -0,0 +1,128 @@
+package sqlq
+
+import (
+	"errors"
+)
+
+var ErrWithEmptyName = errors.New("Name is required to do With Operation, use With() function to specify Name")
+var ErrWithQueryUnsupported = errors.New("Query of With must be Select(), Union() or ValuesList()")
+var ErrWithInsertUnsupported = errors.New("Dialect doesn't support With before Insert")
+var ErrWithColumnsDiffLen = errors.New("Length of With Columns and Values must be the same")
+var ErrValuesEmpty = errors.New("Rows is required to do Values Operation, use Row() function to specify Row")
+var ErrValuesRowsDiffLen = errors.New("Length of every Row in Values must be the same")
+var ErrValuesColumnsRequired = errors.New("Dialect requires With Columns to use ValuesList() as query")
+
+type cte struct {
+	name    string
+	columns []string
+	query   interface{}
+}
+
+type withClause struct {
+	recursive bool
+	ctes      []cte
+}
+
+func (wc *withClause) add(recursive bool, name string, query interface{}, columns []string) {
+	wc.recursive = wc.recursive || recursive
+	wc.ctes = append(wc.ctes, cte{name, columns, query})
+}
+
+func (w *sqlWriter) writeWith(wc withClause) error {
+	if len(wc.ctes) <= 0 {
+		return nil
+	}
+	w.WriteString("WITH ")
+	if wc.recursive && w.dialect.Supports(FeatureRecursiveKeyword) {
+		w.WriteString("RECURSIVE ")
+	}
+	for i, c := range wc.ctes {
+		if c.name == "" {
+			return ErrWithEmptyName
+		}
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.writeAlias(c.name)
+		if len(c.columns) > 0 {
+			w.WriteString(" (")
+			w.writeIdents(c.columns)
+			w.WriteString(")")
+		}
+		w.WriteString(" AS (")
+		switch q := c.query.(type) {
+		case *SelectBuilder, *CompoundBuilder:
+			if isNilQuery(q.(query)) {
+				return ErrSubqueryNil
+			}
+			if err := q.(query).write(w); err != nil {
+				return err
+			}
+		case *ValuesBuilder:
+			if q == nil {
+				return ErrValuesEmpty
+			}
+			if len(c.columns) > 0 && len(q.rows) > 0 && len(c.columns) != len(q.rows[0]) {
+				return ErrWithColumnsDiffLen
+			}
+			if err := q.writeQuery(w, c.columns); err != nil {
+				return err
+			}
+		default:
+			return ErrWithQueryUnsupported
+		}
+		w.WriteString(")")
+	}
+	w.WriteString(" ")
+	return nil
+}
+
+type ValuesBuilder struct {
+	rows [][]interface{}
+}
+
+func (vb *ValuesBuilder) Row(values ...interface{}) *ValuesBuilder {
+	vb.rows = append(vb.rows, values)
+	return vb
+}
+
+func (vb *ValuesBuilder) validate() error {
+	if len(vb.rows) <= 0 || len(vb.rows[0]) <= 0 {
+		return ErrValuesEmpty
+	}
+	for _, row := range vb.rows {
+		if len(row) != len(vb.rows[0]) {
+			return ErrValuesRowsDiffLen
+		}
+	}
+	return nil
+}
+
+// writeQuery writes the rows as a query, dialects without VALUES queries select from them as a derived table.
+func (vb *ValuesBuilder) writeQuery(w *sqlWriter, columns []string) error {
+	if err := vb.validate(); err != nil {
+		return err
+	}
+	if !w.dialect.Supports(FeatureValuesQuery) {
+		if len(columns) <= 0 {
+			return ErrValuesColumnsRequired
+		}
+		w.WriteString("SELECT * FROM (VALUES ")
+		if err := w.writeRows(vb.rows, ""); err != nil {
+			return err
+		}
+		w.WriteString(") AS sqlq_values (")
+		w.writeIdents(columns)
+		w.WriteString(")")
+		return nil
+	}
+	w.WriteString("VALUES ")
+	if w.dialect.Supports(FeatureValuesRow) {
+		return w.writeRows(vb.rows, "ROW")
+	}
+	return w.writeRows(vb.rows, "")
+}
+
+func ValuesList() *ValuesBuilder {
+	return &ValuesBuilder{}
+}
@@ -0,0 +1,143 @@
+package sqlq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValuesList(t *testing.T) {
+	tables := []struct {
+		Result *ValuesBuilder
+		Output *ValuesBuilder
+	}{
+		{
+			ValuesList(),
+			&ValuesBuilder{},
+		},
+		{
+			ValuesList().Row(1, "a").Row(2, "b"),
+			&ValuesBuilder{rows: [][]interface{}{{1, "a"}, {2, "b"}}},
+		},
+	}
+	for _, table := range tables {
+		if !reflect.DeepEqual(table.Result, table.Output) {
+			t.Errorf("Expected %v got %v", table.Output, table.Result)
+		}
+	}
+}
+
+func TestSqlWriter_WriteWith(t *testing.T) {
+	tree := UnionAll(
+		Select("id", "parent_id").From("categories").Where("id", "=", 1),
+		Select("c.id", "c.parent_id").From(As("categories", "c")).Join(As("tree", "t"), On("c.parent_id", "=", "t.id")),
+	)
+	tables := []struct {
+		With    withClause
+		Dialect Dialect
+		Output  string
+		Args    []interface{}
+		Error   error
+	}{
+		{
+			withClause{},
+			MySQL,
+			"",
+			nil,
+			nil,
+		},
+		{
+			withClause{false, []cte{{"paid", nil, Select("user_id").From("orders").Where("status", "=", "paid")}}},
+			PostgreSQL,
+			"WITH paid AS (SELECT user_id FROM orders WHERE status = $1) ",
+			[]interface{}{"paid"},
+			nil,
+		},
+		{
+			withClause{true, []cte{{"tree", []string{"id", "parent_id"}, tree}}},
+			PostgreSQL,
+			"WITH RECURSIVE tree (id, parent_id) AS (SELECT id, parent_id FROM categories WHERE id = $1 " +
+				"UNION ALL SELECT c.id, c.parent_id FROM categories AS c INNER JOIN tree AS t ON c.parent_id = t.id) ",
+			[]interface{}{1},
+			nil,
+		},
+		{
+			withClause{true, []cte{{"tree", []string{"id", "parent_id"}, tree}}},
+			SQLServer,
+			"WITH tree (id, parent_id) AS (SELECT id, parent_id FROM categories WHERE id = @p1 " +
+				"UNION ALL SELECT c.id, c.parent_id FROM categories AS c INNER JOIN tree AS t ON c.parent_id = t.id) ",
+			[]interface{}{1},
+			nil,
+		},
+		{
+			withClause{false, []cte{{"v", []string{"id", "name"}, ValuesList().Row(1, "a").Row(2, "b")}, {"w", nil, Select("id").From("v")}}},
+			PostgreSQL,
+			"WITH v (id, name) AS (VALUES ($1, $2), ($3, $4)), w AS (SELECT id FROM v) ",
+			[]interface{}{1, "a", 2, "b"},
+			nil,
+		},
+		{
+			withClause{false, []cte{{"v", []string{"id", "name"}, ValuesList().Row(1, "a")}}},
+			MySQL,
+			"WITH v (id, name) AS (VALUES ROW(?, ?)) ",
+			[]interface{}{1, "a"},
+			nil,
+		},
+		{
+			withClause{false, []cte{{"v", []string{"id", "name"}, ValuesList().Row(1, "a")}}},
+			SQLServer,
+			"WITH v (id, name) AS (SELECT * FROM (VALUES (@p1, @p2)) AS sqlq_values (id, name)) ",
+			[]interface{}{1, "a"},
+			nil,
+		},
+		{
+			withClause{false, []cte{{"v", nil, ValuesList().Row(1, "a")}}},
+			SQLServer,
+			"WITH v AS (",
+			nil,
+			ErrValuesColumnsRequired,
+		},
+		{
+			withClause{false, []cte{{"v", []string{"id"}, ValuesList().Row(1, "a")}}},
+			PostgreSQL,
+			"WITH v (id) AS (",
+			nil,
+			ErrWithColumnsDiffLen,
+		},
+		{
+			withClause{false, []cte{{"v", nil, ValuesList().Row(1, "a").Row(2)}}},
+			PostgreSQL,
+			"WITH v AS (",
+			nil,
+			ErrValuesRowsDiffLen,
+		},
+		{
+			withClause{false, []cte{{"v", nil, ValuesList()}}},
+			PostgreSQL,
+			"WITH v AS (",
+			nil,
+			ErrValuesEmpty,
+		},
+		{
+			withClause{false, []cte{{"", nil, Select("id").From("users")}}},
+			PostgreSQL,
+			"WITH ",
+			nil,
+			ErrWithEmptyName,
+		},
+		{
+			withClause{false, []cte{{"u", nil, "SELECT 1"}}},
+			PostgreSQL,
+			"WITH u AS (",
+			nil,
+			ErrWithQueryUnsupported,
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(table.Dialect, false)
+		err := w.writeWith(table.With)
+		if w.String() != table.Output || !reflect.DeepEqual(w.args, table.Args) || !errors.Is(err, table.Error) {
+			t.Errorf("%v: Expected %v %v %v got %v %v %v", table.Dialect.Name(), table.Output, table.Args, table.Error, w.String(), w.args, err)
+		}
+	}
+}
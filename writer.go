@@ -0,0 +1,176 @@
+package sqlq
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrColumnUnsupported = errors.New("Column must be a string, Raw() expression or Select() subquery")
+var ErrSubqueryNil = errors.New("Subquery can't be nil, use Select() function to build the subquery")
+
+type sqlWriter struct {
+	strings.Builder
+	dialect Dialect
+	inline  bool
+	args    []interface{}
+	// statement and clause being written, they locate the mistakes found while writing
+	statement string
+	clause    string
+	depth     int
+	problems  []error
+}
+
+func newSqlWriter(dialect Dialect, inline bool) *sqlWriter {
+	if dialect == nil {
+		dialect = defaultDialect
+	}
+	return &sqlWriter{dialect: dialect, inline: inline}
+}
+
+func (w *sqlWriter) writeValue(value interface{}) error {
+	switch v := value.(type) {
+	case Expr:
+		return w.writeExpr(v)
+	case Ident:
+		return w.writeColumn(string(v))
+	case ExcludedColumn:
+		return w.writeExcluded(v)
+	case *SelectBuilder:
+		return w.writeSubquery(v)
+	case *CompoundBuilder:
+		return w.writeSubquery(v)
+	}
+	if w.inline {
+		lit, err := literal(w.dialect, value)
+		if err != nil {
+			return err
+		}
+		w.WriteString(lit)
+		return nil
+	}
+	w.args = append(w.args, value)
+	w.WriteString(w.dialect.Placeholder(len(w.args)))
+	return nil
+}
+
+// writeRows writes rows of values separated by commas, rowPrefix is put before each row like ROW for MySQL.
+func (w *sqlWriter) writeRows(rows [][]interface{}, rowPrefix string) error {
+	for i, row := range rows {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.WriteString(rowPrefix + "(")
+		for j, value := range row {
+			if j > 0 {
+				w.WriteString(", ")
+			}
+			if err := w.writeValue(value); err != nil {
+				return err
+			}
+		}
+		w.WriteString(")")
+	}
+	return nil
+}
+
+func (w *sqlWriter) writeAssignments(assignments []assignment) error {
+	for i, a := range assignments {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.writeIdent(a.column)
+		w.WriteString(" = ")
+		if err := w.writeValue(a.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type query interface {
+	write(w *sqlWriter) error
+}
+
+// isNilQuery reports whether q is nil or a nil builder, which would panic when written.
+func isNilQuery(q query) bool {
+	switch b := q.(type) {
+	case *SelectBuilder:
+		return b == nil
+	case *CompoundBuilder:
+		return b == nil
+	}
+	return q == nil
+}
+
+func (w *sqlWriter) writeSubquery(q query) error {
+	if isNilQuery(q) {
+		return ErrSubqueryNil
+	}
+	w.WriteString("(")
+	if err := q.write(w); err != nil {
+		return err
+	}
+	w.WriteString(")")
+	return nil
+}
+
+func (w *sqlWriter) writeColumn(column interface{}) error {
+	switch c := column.(type) {
+	case string:
+		if numberRegexp.MatchString(c) {
+			// a number selects a constant like SELECT 1
+			w.WriteString(c)
+			return nil
+		}
+		w.writeName(c, true, false)
+	case Expr:
+		return w.writeExpr(c)
+	case *SelectBuilder:
+		return w.writeSubquery(c)
+	case *CompoundBuilder:
+		return w.writeSubquery(c)
+	case windowFunction:
+		return w.writeWindowFunction(c)
+	case aliased:
+		if err := w.writeColumn(c.value); err != nil {
+			return err
+		}
+		w.WriteString(" AS ")
+		w.writeAlias(c.alias)
+	default:
+		return ErrColumnUnsupported
+	}
+	return nil
+}
+
+func (w *sqlWriter) writeColumns(columns []interface{}) error {
+	for i, column := range columns {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		if err := w.writeColumn(column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type orderBy struct {
+	column interface{}
+	order  string
+}
+
+func (w *sqlWriter) writeOrderBy(order []orderBy) error {
+	for i, o := range order {
+		if i == 0 {
+			w.WriteString("ORDER BY ")
+		} else {
+			w.WriteString(", ")
+		}
+		if err := w.writeColumn(o.column); err != nil {
+			return err
+		}
+		w.writeOrder(o.order)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package sqlq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSqlWriter_WriteValue(t *testing.T) {
+	tables := []struct {
+		Inline bool
+		Value  string
+		Output string
+		Args   []interface{}
+	}{
+		{
+			true,
+			"sqlq",
+			"'sqlq'",
+			nil,
+		},
+		{
+			true,
+			"O'Brien",
+			"'O''Brien'",
+			nil,
+		},
+		{
+			false,
+			"O'Brien",
+			"?",
+			[]interface{}{"O'Brien"},
+		},
+	}
+	for _, table := range tables {
+		w := newSqlWriter(MySQL, table.Inline)
+		w.writeValue(table.Value)
+		if w.String() != table.Output || !reflect.DeepEqual(w.args, table.Args) {
+			t.Errorf("Expected %v %v got %v %v", table.Output, table.Args, w.String(), w.args)
+		}
+	}
+}